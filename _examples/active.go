@@ -11,42 +11,40 @@ import (
 	"github.com/makyo/gotui"
 )
 
-var (
-	viewArr = []string{"v1", "v2", "v3", "v4"}
-	active  = 0
-)
-
-func setCurrentViewOnTop(g *gotui.Gui, name string) (*gotui.View, error) {
-	if _, err := g.SetCurrentView(name); err != nil {
-		return nil, err
-	}
-	return g.SetViewOnTop(name)
+func nextView(g *gotui.Gui, v *gotui.View) error {
+	return g.FocusNext()
 }
 
-func nextView(g *gotui.Gui, v *gotui.View) error {
-	nextIndex := (active + 1) % len(viewArr)
-	name := viewArr[nextIndex]
+func prevView(g *gotui.Gui, v *gotui.View) error {
+	return g.FocusPrev()
+}
 
+func onFocus(g *gotui.Gui, v *gotui.View) error {
 	out, err := g.View("v2")
 	if err != nil {
 		return err
 	}
-	fmt.Fprintln(out, "Going from view "+v.Name()+" to "+name)
+	fmt.Fprintln(out, "Focus on view "+v.Name())
 
-	if _, err := setCurrentViewOnTop(g, name); err != nil {
-		return err
-	}
+	g.Cursor = v.Editable
+	_, err = g.SetViewOnTop(v.Name())
+	return err
+}
 
-	if nextIndex == 0 || nextIndex == 3 {
-		g.Cursor = true
-	} else {
-		g.Cursor = false
+func onBlur(g *gotui.Gui, v *gotui.View) error {
+	out, err := g.View("v2")
+	if err != nil {
+		return err
 	}
-
-	active = nextIndex
+	fmt.Fprintln(out, "Leaving view "+v.Name())
 	return nil
 }
 
+func setFocusHandlers(v *gotui.View) {
+	v.OnFocus = onFocus
+	v.OnBlur = onBlur
+}
+
 func layout(g *gotui.Gui) error {
 	maxX, maxY := g.Size()
 	if v, err := g.SetView("v1", 0, 0, maxX/2-1, maxY/2-1); err != nil {
@@ -56,10 +54,7 @@ func layout(g *gotui.Gui) error {
 		v.Title = "v1 (editable)"
 		v.Editable = true
 		v.Wrap = true
-
-		if _, err = setCurrentViewOnTop(g, "v1"); err != nil {
-			return err
-		}
+		setFocusHandlers(v)
 	}
 
 	if v, err := g.SetView("v2", maxX/2-1, 0, maxX-1, maxY/2-1); err != nil {
@@ -69,6 +64,7 @@ func layout(g *gotui.Gui) error {
 		v.Title = "v2"
 		v.Wrap = true
 		v.Autoscroll = true
+		setFocusHandlers(v)
 	}
 	if v, err := g.SetView("v3", 0, maxY/2-1, maxX/2-1, maxY-1); err != nil {
 		if err != gotui.ErrUnknownView {
@@ -77,7 +73,8 @@ func layout(g *gotui.Gui) error {
 		v.Title = "v3"
 		v.Wrap = true
 		v.Autoscroll = true
-		fmt.Fprint(v, "Press TAB to change current view")
+		fmt.Fprint(v, "Press TAB or Shift+TAB to change current view")
+		setFocusHandlers(v)
 	}
 	if v, err := g.SetView("v4", maxX/2, maxY/2, maxX-1, maxY-1); err != nil {
 		if err != gotui.ErrUnknownView {
@@ -85,6 +82,12 @@ func layout(g *gotui.Gui) error {
 		}
 		v.Title = "v4 (editable)"
 		v.Editable = true
+		setFocusHandlers(v)
+
+		// all the views exist, focus the first one
+		if err := g.FocusNext(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -112,6 +115,9 @@ func main() {
 	if err := g.SetKeybinding("", gotui.KeyTab, gotui.ModNone, nextView); err != nil {
 		log.Panicln(err)
 	}
+	if err := g.SetKeybinding("", gotui.KeyTab, gotui.ModShift, prevView); err != nil {
+		log.Panicln(err)
+	}
 
 	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
 		log.Panicln(err)
@@ -0,0 +1,120 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/makyo/gotui"
+)
+
+func layout(g *gotui.Gui) error {
+	maxX, maxY := g.Size()
+	if v, err := g.SetView("main", 0, 0, maxX-1, maxY-1); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "a: alert, c: confirm, p: prompt, s: save, q: quit"
+		v.Autoscroll = true
+		if _, err := g.SetCurrentView("main"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func logf(g *gotui.Gui, format string, a ...interface{}) error {
+	v, err := g.View("main")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(v, format+"\n", a...)
+	return nil
+}
+
+func alert(g *gotui.Gui, v *gotui.View) error {
+	return g.Alert("Alert", "Something happened.", func(g *gotui.Gui) error {
+		return logf(g, "alert closed")
+	})
+}
+
+func confirm(g *gotui.Gui, v *gotui.View) error {
+	return g.Confirm("Confirm", "Do you want to continue?", func(g *gotui.Gui, ok bool) error {
+		return logf(g, "confirmed: %v", ok)
+	})
+}
+
+func prompt(g *gotui.Gui, v *gotui.View) error {
+	return g.Prompt("Prompt", "What is your name?", "", func(g *gotui.Gui, text string, ok bool) error {
+		if !ok {
+			return logf(g, "prompt canceled")
+		}
+		return logf(g, "hello, %s", text)
+	})
+}
+
+func save(g *gotui.Gui, v *gotui.View) error {
+	result, err := g.ShowDialog(gotui.Dialog{
+		Title:   "Save changes",
+		Message: "The document has been modified.\nDo you want to save the changes?",
+		Buttons: []string{"Save", "Discard", "Cancel"},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the result can also be received from a goroutine
+	go func() {
+		r := <-result
+		g.Update(func(g *gotui.Gui) error {
+			return logf(g, "save dialog closed with button %d", r.Button)
+		})
+	}()
+	return nil
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return g.Confirm("Quit", "Are you sure?", func(g *gotui.Gui, ok bool) error {
+		if ok {
+			return gotui.ErrQuit
+		}
+		return nil
+	})
+}
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Cursor = true
+	g.Mouse = true
+	g.InputEsc = true
+
+	g.SetManagerFunc(layout)
+
+	bindings := []struct {
+		ch      rune
+		handler func(*gotui.Gui, *gotui.View) error
+	}{
+		{'a', alert},
+		{'c', confirm},
+		{'p', prompt},
+		{'s', save},
+		{'q', quit},
+	}
+	for _, b := range bindings {
+		if err := g.SetKeybinding("", b.ch, gotui.ModNone, b.handler); err != nil {
+			log.Panicln(err)
+		}
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
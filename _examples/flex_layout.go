@@ -0,0 +1,65 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/makyo/gotui"
+	"github.com/makyo/gotui/layout"
+)
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	root := layout.Column(
+		layout.Row(
+			&layout.View{Name: "side", Size: layout.Percent(25).Min(20), Init: title("side (25%, min 20)")},
+			&layout.Box{
+				Direction: layout.Vertical,
+				Gap:       -1,
+				Children: []layout.Node{
+					&layout.View{Name: "main", Size: layout.Ratio(2), Init: title("main (ratio 2)")},
+					&layout.View{Name: "detail", Init: title("detail (ratio 1)")},
+				},
+			},
+			&layout.View{Name: "info", Size: layout.Ratio(1).Max(30), Init: title("info (max 30)")},
+		),
+		&layout.View{Name: "status", Size: layout.Fixed(1), Init: status},
+	)
+	root.Padding = gotui.Padding{Top: 1, Right: 1, Bottom: 0, Left: 1}
+	g.SetManager(layout.NewManager(root))
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func title(t string) func(v *gotui.View) error {
+	return func(v *gotui.View) error {
+		v.Title = t
+		return nil
+	}
+}
+
+func status(v *gotui.View) error {
+	v.Frame = false
+	v.BgColor = gotui.ColorBlue
+	fmt.Fprint(v, "Resize the terminal to see the layout adapt. ^C: Exit")
+	return nil
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
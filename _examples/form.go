@@ -0,0 +1,101 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"github.com/makyo/gotui"
+)
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Cursor = true
+	g.Mouse = true
+	g.InputEsc = true
+
+	form := gotui.NewForm("setup", 0, 0, 0, 0)
+	form.Title = "Server setup"
+
+	host := form.AddInput("host", "Host:")
+	host.Placeholder = "example.com"
+	host.Validate = func(text string) error {
+		if text == "" {
+			return errors.New("required")
+		}
+		return nil
+	}
+
+	port := form.AddInput("port", "Port:")
+	port.SetText("8080")
+	port.MaxLength = 5
+	port.Accept = unicode.IsDigit
+	port.Validate = func(text string) error {
+		if n, err := strconv.Atoi(text); err != nil || n < 1 || n > 65535 {
+			return errors.New("must be between 1 and 65535")
+		}
+		return nil
+	}
+
+	password := form.AddInput("password", "Password:")
+	password.Mask = '*'
+
+	form.AddCheckbox("tls", "Use TLS:", true)
+	form.AddRadio("env", "Environment:", []string{"Development", "Staging", "Production"}, 0)
+	form.AddDropdown("region", "Region:", []string{"eu-west", "eu-central", "us-east", "us-west", "ap-south"}, 0)
+
+	form.Validate = func(values map[string]interface{}) error {
+		if values["env"] == "Production" && values["password"] == "" {
+			return errors.New("a password is required in production")
+		}
+		return nil
+	}
+	form.OnSubmit = func(g *gotui.Gui, f *gotui.Form, values map[string]interface{}) error {
+		var keys []string
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		msg := ""
+		for _, k := range keys {
+			msg += fmt.Sprintf("%s: %v\n", k, values[k])
+		}
+		return g.Alert("Submitted", msg, nil)
+	}
+	form.OnCancel = func(g *gotui.Gui, f *gotui.Form) error {
+		return gotui.ErrQuit
+	}
+	form.AddButton("OK", form.Submit)
+	form.AddButton("Cancel", form.Cancel)
+
+	g.SetManagerFunc(func(g *gotui.Gui) error {
+		maxX, maxY := g.Size()
+		x0, y0 := maxX/2-25, maxY/2-8
+		form.SetPosition(x0, y0, x0+50, y0+15)
+		return form.Layout(g)
+	})
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
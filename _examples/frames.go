@@ -0,0 +1,95 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/makyo/gotui"
+)
+
+var panes = []struct {
+	name  string
+	style gotui.FrameStyle
+}{
+	{"single", gotui.FrameSingle},
+	{"rounded", gotui.FrameRounded},
+	{"double", gotui.FrameDouble},
+	{"dashed", gotui.FrameDashed},
+	{"no sides", gotui.FrameStyle{HideLeft: true, HideRight: true}},
+	{"custom", gotui.FrameStyle{
+		Horizontal: '~', Vertical: ':',
+		TopLeft: '*', TopRight: '*', BottomLeft: '*', BottomRight: '*',
+	}},
+}
+
+var active = 0
+
+func layout(g *gotui.Gui) error {
+	maxX, maxY := g.Size()
+	w := maxX / 3
+	h := maxY / 2
+	for i, p := range panes {
+		x0, y0 := (i%3)*w, (i/3)*h
+		x1, y1 := x0+w, y0+h
+		if i%3 == 2 {
+			x1 = maxX - 1
+		}
+		if i/3 == 1 {
+			y1 = maxY - 1
+		}
+		v, err := g.SetView(p.name, x0, y0, x1, y1)
+		if err != nil {
+			if err != gotui.ErrUnknownView {
+				return err
+			}
+			v.Title = p.name
+			v.FrameStyle = p.style
+			fmt.Fprintln(v, "Press TAB to change the current view")
+		}
+	}
+	if g.CurrentView() == nil {
+		if _, err := g.SetCurrentView(panes[active].name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func nextView(g *gotui.Gui, v *gotui.View) error {
+	active = (active + 1) % len(panes)
+	_, err := g.SetCurrentView(panes[active].name)
+	return err
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Highlight = true
+	g.SelFgColor = gotui.ColorGreen
+	g.SelFrameStyle = gotui.FrameHeavy
+
+	g.SetManagerFunc(layout)
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding("", gotui.KeyTab, gotui.ModNone, nextView); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/makyo/gotui"
+	"github.com/makyo/gotui/layout"
+)
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	grid := &layout.Grid{
+		Rows:    []layout.Size{layout.Auto(), layout.Ratio(1), layout.Ratio(1)},
+		Columns: []layout.Size{layout.Fixed(24), layout.Ratio(1), layout.Ratio(1)},
+		Gap:     -1,
+		Areas: []string{
+			"header header header",
+			"hosts  cpu    memory",
+			"hosts  disk   network",
+		},
+		Items: []*layout.GridItem{
+			{Node: pane("header", "Monitoring dashboard\n^C: Exit"), Area: "header"},
+			{Node: pane("hosts", "web-1\nweb-2\ndb-1"), Area: "hosts"},
+			{Node: pane("cpu", "12%"), Area: "cpu"},
+			{Node: pane("memory", "3.2G / 8G"), Area: "memory"},
+			{Node: pane("disk", "40%"), Row: 2, Column: 1},
+			{Node: pane("network", "1.2 MB/s"), Row: 2, Column: 2},
+		},
+	}
+	g.SetManager(layout.NewManager(grid))
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func pane(name, body string) *layout.View {
+	return &layout.View{
+		Name: name,
+		Init: func(v *gotui.View) error {
+			v.Title = name
+			fmt.Fprint(v, body)
+			return nil
+		},
+	}
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
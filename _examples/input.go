@@ -0,0 +1,113 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"unicode"
+
+	"github.com/makyo/gotui"
+)
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Cursor = true
+	g.Mouse = true
+	g.InputEsc = true
+
+	command := gotui.NewTextInput("command", 0, 0, 0, 0)
+	command.Title = "Command (up/down: history)"
+	command.Placeholder = "type a command and press enter"
+	command.ClearOnSubmit = true
+	command.OnSubmit = func(g *gotui.Gui, t *gotui.TextInput, text string) error {
+		return logf(g, "> %s", text)
+	}
+	command.OnCancel = func(g *gotui.Gui, t *gotui.TextInput) error {
+		t.SetText("")
+		return nil
+	}
+
+	password := gotui.NewTextInput("password", 0, 0, 0, 0)
+	password.Title = "Password"
+	password.Placeholder = "at least 8 characters"
+	password.Mask = '*'
+	password.Validate = func(text string) error {
+		if len([]rune(text)) < 8 {
+			return errors.New("too short")
+		}
+		return nil
+	}
+	password.OnSubmit = func(g *gotui.Gui, t *gotui.TextInput, text string) error {
+		return logf(g, "password set (%d characters)", len([]rune(text)))
+	}
+
+	port := gotui.NewTextInput("port", 0, 0, 0, 0)
+	port.Title = "Port"
+	port.Placeholder = "8080"
+	port.MaxLength = 5
+	port.Accept = unicode.IsDigit
+	port.OnSubmit = func(g *gotui.Gui, t *gotui.TextInput, text string) error {
+		return logf(g, "port: %s", text)
+	}
+
+	g.SetManagerFunc(func(g *gotui.Gui) error {
+		maxX, maxY := g.Size()
+		command.SetPosition(0, 0, maxX-1, 2)
+		password.SetPosition(0, 3, maxX/2-1, 5)
+		port.SetPosition(maxX/2, 3, maxX-1, 5)
+		for _, w := range []gotui.Widget{command, password, port} {
+			if err := w.Layout(g); err != nil {
+				return err
+			}
+		}
+		if v, err := g.SetView("log", 0, 6, maxX-1, maxY-1); err != nil {
+			if err != gotui.ErrUnknownView {
+				return err
+			}
+			v.Title = "Tab: next field, ^C: exit"
+			v.Autoscroll = true
+			v.Focusable = false
+			if _, err := g.SetCurrentView("command"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err := g.SetKeybinding("", gotui.KeyTab, gotui.ModNone, focusNext); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func logf(g *gotui.Gui, format string, a ...interface{}) error {
+	v, err := g.View("log")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(v, format+"\n", a...)
+	return nil
+}
+
+func focusNext(g *gotui.Gui, v *gotui.View) error {
+	return g.FocusNext()
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
@@ -0,0 +1,136 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/makyo/gotui"
+)
+
+var count = 0
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.SetManagerFunc(layout)
+
+	if err := keybindings(g); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func layout(g *gotui.Gui) error {
+	maxX, maxY := g.Size()
+	if v, err := g.SetView("background", 0, 0, maxX-1, maxY-1); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Layer = gotui.LayerBackground
+		fmt.Fprintln(v, "n: new view, d: show dialog, h: hide dialog, arrows: move dialog, ^C: exit")
+	}
+	return nil
+}
+
+func newView(g *gotui.Gui, v *gotui.View) error {
+	name := fmt.Sprintf("v%d", count)
+	x, y := 5+count*3, 3+count*2
+	v, err := g.SetView(name, x, y, x+25, y+5)
+	if err != nil && err != gotui.ErrUnknownView {
+		return err
+	}
+	fmt.Fprintf(v, "View #%d\nCreated on top", count)
+	count++
+	return nil
+}
+
+func showDialog(g *gotui.Gui, v *gotui.View) error {
+	if len(g.GroupViews("dialog")) > 0 {
+		if err := g.SetGroupVisible("dialog", true); err != nil {
+			return err
+		}
+		return g.SetGroupOnTop("dialog")
+	}
+
+	maxX, maxY := g.Size()
+	x0, y0 := maxX/2-15, maxY/2-4
+	views := []struct {
+		name           string
+		x0, y0, x1, y1 int
+		text           string
+	}{
+		{"dialog", x0, y0, x0 + 30, y0 + 8, "Dialogs stay on top\nof normal views."},
+		{"ok", x0 + 4, y0 + 4, x0 + 13, y0 + 6, "   OK"},
+		{"cancel", x0 + 16, y0 + 4, x0 + 26, y0 + 6, " Cancel"},
+	}
+	for i, d := range views {
+		v, err := g.SetView(d.name, d.x0, d.y0, d.x1, d.y1)
+		if err != nil && err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Layer = gotui.LayerPopup
+		v.ZIndex = i
+		v.Group = "dialog"
+		fmt.Fprint(v, d.text)
+	}
+	return nil
+}
+
+func hideDialog(g *gotui.Gui, v *gotui.View) error {
+	if err := g.SetGroupVisible("dialog", false); err != nil && err != gotui.ErrUnknownView {
+		return err
+	}
+	return nil
+}
+
+func moveDialog(dx, dy int) func(g *gotui.Gui, v *gotui.View) error {
+	return func(g *gotui.Gui, v *gotui.View) error {
+		if err := g.MoveGroup("dialog", dx, dy); err != nil && err != gotui.ErrUnknownView {
+			return err
+		}
+		return nil
+	}
+}
+
+func keybindings(g *gotui.Gui) error {
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'n', gotui.ModNone, newView); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'd', gotui.ModNone, showDialog); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'h', gotui.ModNone, hideDialog); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gotui.KeyArrowLeft, gotui.ModNone, moveDialog(-1, 0)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gotui.KeyArrowRight, gotui.ModNone, moveDialog(1, 0)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gotui.KeyArrowUp, gotui.ModNone, moveDialog(0, -1)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gotui.KeyArrowDown, gotui.ModNone, moveDialog(0, 1)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
@@ -0,0 +1,102 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/makyo/gotui"
+	"github.com/makyo/gotui/layout"
+)
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Mouse = true
+	g.InputEsc = true
+
+	items := make([]string, 100000)
+	for i := range items {
+		items[i] = fmt.Sprintf("Item number %d", i)
+	}
+	numbers := gotui.NewList("numbers", 0, 0, 0, 0, items)
+	numbers.Title = "100000 items (type to filter)"
+	numbers.Filterable = true
+	numbers.OnSelect = func(g *gotui.Gui, l *gotui.List, index int) error {
+		return status(g, "selected: %s", l.Items()[index])
+	}
+
+	colors := gotui.NewList("colors", 0, 0, 0, 0, []string{
+		"Red", "Green", "Yellow", "Blue", "Magenta", "Cyan", "White",
+	})
+	colors.Title = "Colors (space to toggle)"
+	colors.Multi = true
+	colors.Render = func(index int) string {
+		return fmt.Sprintf("\x1b[3%dm%s\x1b[0m", index+1, colors.Items()[index])
+	}
+	colors.OnChange = func(g *gotui.Gui, l *gotui.List) error {
+		var names []string
+		for _, i := range l.Selected() {
+			names = append(names, l.Items()[i])
+		}
+		return status(g, "colors: %v", names)
+	}
+
+	root := layout.Column(
+		layout.Row(
+			&layout.Widget{Widget: numbers, Size: layout.Ratio(2)},
+			&layout.Widget{Widget: colors},
+		),
+		&layout.View{Name: "status", Size: layout.Fixed(3), Init: func(v *gotui.View) error {
+			v.Focusable = false
+			return nil
+		}},
+	)
+	g.SetManagerFunc(func(g *gotui.Gui) error {
+		if err := layout.NewManager(root).Layout(g); err != nil {
+			return err
+		}
+		if g.CurrentView() == nil {
+			if _, err := g.SetCurrentView("numbers"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err := g.SetKeybinding("", gotui.KeyTab, gotui.ModNone, focusNext); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func status(g *gotui.Gui, format string, a ...interface{}) error {
+	v, err := g.View("status")
+	if err != nil {
+		return nil
+	}
+	v.Clear()
+	fmt.Fprintf(v, format, a...)
+	return nil
+}
+
+func focusNext(g *gotui.Gui, v *gotui.View) error {
+	return g.FocusNext()
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
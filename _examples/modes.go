@@ -0,0 +1,145 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/makyo/gotui"
+)
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Cursor = true
+	g.InputEsc = true
+
+	g.SetManagerFunc(layout)
+
+	if err := g.SetMode(gotui.ModeNormal); err != nil {
+		log.Panicln(err)
+	}
+	g.SetModeFunc(modeChanged)
+
+	if err := keybindings(g); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func layout(g *gotui.Gui) error {
+	maxX, maxY := g.Size()
+	if v, err := g.SetView("main", 0, 0, maxX-1, maxY-3); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Editable = true
+		v.Wrap = true
+		fmt.Fprintln(v, "Press i to insert text, Esc to go back to normal mode.")
+		fmt.Fprintln(v, "In normal mode, h/j/k/l move the cursor and : opens the command line.")
+		fmt.Fprintln(v, "Type :q and press Enter to quit.")
+		if _, err := g.SetCurrentView("main"); err != nil {
+			return err
+		}
+	}
+	if v, err := g.SetView("cmdline", 0, maxY-3, maxX-1, maxY-1); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = string(gotui.ModeNormal)
+		v.Editable = true
+		v.Editor = gotui.EditorFunc(cmdlineEditor)
+	}
+	return nil
+}
+
+func keybindings(g *gotui.Gui) error {
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		return err
+	}
+
+	moves := map[rune][2]int{'h': {-1, 0}, 'j': {0, 1}, 'k': {0, -1}, 'l': {1, 0}}
+	for ch, d := range moves {
+		if err := g.SetModeKeybinding(gotui.ModeNormal, "main", ch, gotui.ModNone, move(d[0], d[1])); err != nil {
+			return err
+		}
+	}
+	if err := g.SetModeKeybinding(gotui.ModeNormal, "main", 'i', gotui.ModNone, setMode(gotui.ModeInsert)); err != nil {
+		return err
+	}
+	if err := g.SetModeKeybinding(gotui.ModeNormal, "main", ':', gotui.ModNone, setMode(gotui.ModeCommand)); err != nil {
+		return err
+	}
+	if err := g.SetModeKeybinding(gotui.ModeInsert, "", gotui.KeyEsc, gotui.ModNone, setMode(gotui.ModeNormal)); err != nil {
+		return err
+	}
+	if err := g.SetModeKeybinding(gotui.ModeCommand, "", gotui.KeyEsc, gotui.ModNone, setMode(gotui.ModeNormal)); err != nil {
+		return err
+	}
+	if err := g.SetModeKeybinding(gotui.ModeCommand, "cmdline", gotui.KeyEnter, gotui.ModNone, runCommand); err != nil {
+		return err
+	}
+	return nil
+}
+
+func modeChanged(g *gotui.Gui, from, to gotui.Mode) error {
+	v, err := g.View("cmdline")
+	if err != nil {
+		return err
+	}
+	v.Title = string(to)
+
+	next := "main"
+	if to == gotui.ModeCommand {
+		next = "cmdline"
+		v.Clear()
+		if err := v.SetCursor(0, 0); err != nil {
+			return err
+		}
+	}
+	_, err = g.SetCurrentView(next)
+	return err
+}
+
+func cmdlineEditor(v *gotui.View, key gotui.Key, ch rune, mod gotui.Modifier) {
+	if key == gotui.KeyEnter {
+		return
+	}
+	gotui.DefaultEditor.Edit(v, key, ch, mod)
+}
+
+func runCommand(g *gotui.Gui, v *gotui.View) error {
+	cmd := strings.TrimSpace(v.Buffer())
+	if cmd == "q" {
+		return gotui.ErrQuit
+	}
+	return g.SetMode(gotui.ModeNormal)
+}
+
+func move(dx, dy int) func(g *gotui.Gui, v *gotui.View) error {
+	return func(g *gotui.Gui, v *gotui.View) error {
+		v.MoveCursor(dx, dy, false)
+		return nil
+	}
+}
+
+func setMode(mode gotui.Mode) func(g *gotui.Gui, v *gotui.View) error {
+	return func(g *gotui.Gui, v *gotui.View) error {
+		return g.SetMode(mode)
+	}
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
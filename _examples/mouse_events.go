@@ -0,0 +1,94 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/makyo/gotui"
+)
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Mouse = true
+	g.MouseMotion = true
+
+	g.SetManagerFunc(layout)
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetMouseBinding("", logEvent); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func layout(g *gotui.Gui) error {
+	maxX, maxY := g.Size()
+	if v, err := g.SetView("left", 0, 0, maxX/2-1, maxY-5); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "left"
+	}
+	if v, err := g.SetView("right", maxX/2, 0, maxX-1, maxY-5); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "right"
+	}
+	if v, err := g.SetView("log", 0, maxY-4, maxX-1, maxY-1); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "events"
+		v.Autoscroll = true
+	}
+	return nil
+}
+
+func logEvent(g *gotui.Gui, v *gotui.View, ev *gotui.MouseEvent) error {
+	out, err := g.View("log")
+	if err != nil {
+		return err
+	}
+
+	// Keep receiving the events of a view while dragging from it.
+	if v != nil && v != out && ev.Action == gotui.MouseActionPress && ev.Button == gotui.MouseLeft {
+		if err := g.CaptureMouse(v.Name()); err != nil {
+			return err
+		}
+	}
+
+	name := "(none)"
+	if v != nil {
+		name = v.Name()
+	}
+	actions := map[gotui.MouseAction]string{
+		gotui.MouseActionPress:   "press",
+		gotui.MouseActionRelease: "release",
+		gotui.MouseActionDrag:    "drag",
+		gotui.MouseActionMotion:  "motion",
+		gotui.MouseActionEnter:   "enter",
+		gotui.MouseActionLeave:   "leave",
+	}
+	fmt.Fprintf(out, "%s: %s button=%d clicks=%d mod=%d abs=(%d,%d) rel=(%d,%d)\n",
+		name, actions[ev.Action], ev.Button, ev.Clicks, ev.Mod, ev.X, ev.Y, ev.ViewX, ev.ViewY)
+	return nil
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
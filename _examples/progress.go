@@ -0,0 +1,136 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/makyo/gotui"
+)
+
+const numJobs = 20
+
+type job struct {
+	bar  *gotui.ProgressBar
+	done bool
+}
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	spinner := gotui.NewSpinner("spinner", 0, 0, 0, 0)
+	load := gotui.NewGauge("load", 0, 0, 0, 0, 0, numJobs)
+	load.Label = "running"
+	load.Format = func(value float64) string {
+		return fmt.Sprintf("%2.0f/%d", value, numJobs)
+	}
+	load.Thresholds = []gotui.GaugeThreshold{
+		{Value: numJobs / 2, Color: gotui.ColorYellow},
+		{Value: numJobs * 3 / 4, Color: gotui.ColorRed},
+	}
+
+	jobs := make([]*job, numJobs)
+	for i := range jobs {
+		bar := gotui.NewProgressBar(fmt.Sprintf("job%d", i), 0, 0, 0, 0)
+		bar.Label = fmt.Sprintf("job %2d", i+1)
+		bar.ShowPercent = true
+		bar.ShowETA = true
+		bar.FgColor = gotui.ColorGreen
+		// jobs of unknown length
+		bar.Indeterminate = i%5 == 4
+		jobs[i] = &job{bar: bar}
+	}
+
+	g.SetManagerFunc(func(g *gotui.Gui) error {
+		maxX, _ := g.Size()
+		running := 0
+		for i, j := range jobs {
+			j.bar.SetPosition(0, i, maxX-1, i+2)
+			if err := j.bar.Layout(g); err != nil {
+				return err
+			}
+			if v, err := g.View(j.bar.Name()); err == nil {
+				v.Frame = false
+			}
+			if !j.done {
+				running++
+			}
+		}
+
+		y := len(jobs) + 2
+		load.SetValue(float64(running))
+		load.SetPosition(0, y, maxX-1, y+2)
+		if err := load.Layout(g); err != nil {
+			return err
+		}
+
+		spinner.Label = fmt.Sprintf("%d jobs running (a: toggle ASCII, ^C: exit)", running)
+		if running == 0 {
+			spinner.Label = "all the jobs are done (a: toggle ASCII, ^C: exit)"
+			spinner.Stop()
+		}
+		spinner.SetPosition(0, y+3, maxX-1, y+5)
+		return spinner.Layout(g)
+	})
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding("", 'a', gotui.ModNone, toggleASCII); err != nil {
+		log.Panicln(err)
+	}
+
+	for _, j := range jobs {
+		go run(g, j)
+	}
+	go redraw(g)
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+// run simulates a job, which reports its progress every few steps.
+func run(g *gotui.Gui, j *job) {
+	steps := 20 + rand.Intn(80)
+	delay := time.Duration(50+rand.Intn(150)) * time.Millisecond
+	for i := 1; i <= steps; i++ {
+		time.Sleep(delay)
+		value := float64(i) / float64(steps)
+		g.Update(func(g *gotui.Gui) error {
+			j.bar.SetValue(value)
+			return nil
+		})
+	}
+	g.Update(func(g *gotui.Gui) error {
+		j.bar.Indeterminate = false
+		j.bar.SetValue(1)
+		j.done = true
+		return nil
+	})
+}
+
+// redraw redraws the GUI periodically, so the animations move.
+func redraw(g *gotui.Gui) {
+	for range time.Tick(100 * time.Millisecond) {
+		g.Update(func(g *gotui.Gui) error { return nil })
+	}
+}
+
+func toggleASCII(g *gotui.Gui, v *gotui.View) error {
+	g.ASCII = !g.ASCII
+	return nil
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
@@ -0,0 +1,65 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/makyo/gotui"
+)
+
+func layout(g *gotui.Gui) error {
+	maxX, maxY := g.Size()
+	if v, err := g.SetView("log", 0, 0, maxX/2-1, maxY-1); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Frame scrollbars"
+		v.VScrollbar = true
+		v.HScrollbar = true
+		for i := 0; i < 200; i++ {
+			fmt.Fprintf(v, "%03d %s\n", i, strings.Repeat("log line ", i%12+1))
+		}
+	}
+	if v, err := g.SetView("inside", maxX/2, 0, maxX-1, maxY-1); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Inside scrollbar"
+		v.VScrollbar = true
+		v.ScrollbarInside = true
+		v.Wrap = true
+		for i := 0; i < 100; i++ {
+			fmt.Fprintf(v, "%03d %s\n", i, strings.Repeat("wrapped text ", i%8+1))
+		}
+	}
+	return nil
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Mouse = true
+
+	g.SetManagerFunc(layout)
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
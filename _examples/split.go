@@ -0,0 +1,88 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/makyo/gotui"
+	"github.com/makyo/gotui/layout"
+)
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Mouse = true
+	g.SelFgColor = gotui.ColorGreen
+
+	inner := &layout.SplitPane{
+		Name:      "inner",
+		Direction: layout.Vertical,
+		First:     pane("main", "Drag the dividers with the mouse."),
+		Second:    pane("console", "Alt+Up/Alt+Down move this divider."),
+		Ratio:     0.7,
+		MinFirst:  3,
+		MinSecond: 3,
+		ShrinkKey: gotui.KeyArrowUp,
+		GrowKey:   gotui.KeyArrowDown,
+		KeyMod:    gotui.ModAlt,
+	}
+	outer := &layout.SplitPane{
+		Name:      "outer",
+		First:     pane("sidebar", "Alt+Left/Alt+Right move this divider."),
+		Second:    inner,
+		Ratio:     0.25,
+		MinFirst:  10,
+		MinSecond: 20,
+		ShrinkKey: gotui.KeyArrowLeft,
+		GrowKey:   gotui.KeyArrowRight,
+		KeyMod:    gotui.ModAlt,
+		OnChange: func(g *gotui.Gui, s *layout.SplitPane) error {
+			v, err := g.View("sidebar")
+			if err != nil {
+				return err
+			}
+			v.Title = fmt.Sprintf("sidebar (%.0f%%)", s.Ratio*100)
+			return nil
+		},
+	}
+	g.SetManager(layout.NewManager(outer), gotui.ManagerFunc(focus))
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func pane(name, body string) *layout.View {
+	return &layout.View{
+		Name: name,
+		Init: func(v *gotui.View) error {
+			v.Title = name
+			fmt.Fprint(v, body)
+			return nil
+		},
+	}
+}
+
+func focus(g *gotui.Gui) error {
+	if g.CurrentView() == nil {
+		_, err := g.SetCurrentView("main")
+		return err
+	}
+	return nil
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
@@ -37,12 +37,13 @@ func main() {
 func layout(g *gotui.Gui) error {
 	maxX, _ := g.Size()
 
-	if v, err := g.SetView("help", maxX-23, 0, maxX-1, 5); err != nil {
+	if v, err := g.SetView("help", maxX-23, 0, maxX-1, 6); err != nil {
 		if err != gotui.ErrUnknownView {
 			return err
 		}
 		fmt.Fprintln(v, "KEYBINDINGS")
 		fmt.Fprintln(v, "↑ ↓: Seek input")
+		fmt.Fprintln(v, "PgUp PgDn Home End")
 		fmt.Fprintln(v, "a: Enable autoscroll")
 		fmt.Fprintln(v, "^C: Exit")
 	}
@@ -71,18 +72,7 @@ func initKeybindings(g *gotui.Gui) error {
 	if err := g.SetKeybinding("stdin", 'a', gotui.ModNone, autoscroll); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("stdin", gotui.KeyArrowUp, gotui.ModNone,
-		func(g *gotui.Gui, v *gotui.View) error {
-			scrollView(v, -1)
-			return nil
-		}); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding("stdin", gotui.KeyArrowDown, gotui.ModNone,
-		func(g *gotui.Gui, v *gotui.View) error {
-			scrollView(v, 1)
-			return nil
-		}); err != nil {
+	if err := g.SetScrollKeybindings("stdin"); err != nil {
 		return err
 	}
 	return nil
@@ -96,14 +86,3 @@ func autoscroll(g *gotui.Gui, v *gotui.View) error {
 	v.Autoscroll = true
 	return nil
 }
-
-func scrollView(v *gotui.View, dy int) error {
-	if v != nil {
-		v.Autoscroll = false
-		ox, oy := v.Origin()
-		if err := v.SetOrigin(ox, oy+dy); err != nil {
-			return err
-		}
-	}
-	return nil
-}
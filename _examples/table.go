@@ -0,0 +1,86 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+
+	"github.com/makyo/gotui"
+)
+
+var commands = []string{
+	"bash", "vim main.go", "go build ./...", "sshd: user@pts/0",
+	"日本語のコマンド", "top", "/usr/lib/systemd/systemd --user",
+}
+
+func main() {
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Mouse = true
+
+	table := gotui.NewTable("processes", 0, 0, 0, 0, []gotui.TableColumn{
+		{Title: "PID", Align: gotui.AlignRight},
+		{Title: "USER", Sizing: gotui.ColumnFixed, Width: 8},
+		{Title: "CPU%", Align: gotui.AlignRight},
+		{Title: "MEM%", Align: gotui.AlignRight},
+		{Title: "COMMAND", Sizing: gotui.ColumnFlex, MinWidth: 20},
+	})
+	table.Title = "arrows: move, s: sort, <>: resize, ^C: exit"
+
+	var rows [][]gotui.TableCell
+	for i := 0; i < 200; i++ {
+		cpu := rand.Float64() * 100
+		row := gotui.TableCells(
+			fmt.Sprint(1000+rand.Intn(30000)),
+			[]string{"root", "user", "www-data"}[rand.Intn(3)],
+			fmt.Sprintf("%.1f", cpu),
+			fmt.Sprintf("%.1f", rand.Float64()*10),
+			commands[rand.Intn(len(commands))],
+		)
+		if cpu > 80 {
+			row[2].FgColor = gotui.ColorRed | gotui.AttrBold
+		}
+		rows = append(rows, row)
+	}
+	table.SetRows(rows)
+	table.SortBy(2, true)
+
+	table.OnSelect = func(g *gotui.Gui, t *gotui.Table, row int) error {
+		cells := t.Rows()[row]
+		return g.Alert("Process", fmt.Sprintf("%s (PID %s)", cells[4].Text, cells[0].Text), nil)
+	}
+
+	g.SetManagerFunc(func(g *gotui.Gui) error {
+		maxX, maxY := g.Size()
+		table.SetPosition(0, 0, maxX-1, maxY-1)
+		if err := table.Layout(g); err != nil {
+			return err
+		}
+		if g.CurrentView() == nil {
+			if _, err := g.SetCurrentView("processes"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
@@ -158,5 +158,48 @@ func layout(g *gotui.Gui) error {
 		v.Title = "Regular title"
 	}
 
+	// Alignment
+	if v, err := g.SetView("v19", 10, 36, 30, 40); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Centered"
+		v.TitleAlign = gotui.AlignCenter
+	}
+	if v, err := g.SetView("v20", 35, 36, 55, 40); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Right"
+		v.TitleAlign = gotui.AlignRight
+	}
+
+	// Segments and footer
+	if v, err := g.SetView("v21", 60, 36, 100, 40); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Name"
+		v.TitleSegments = []gotui.TitleSegment{
+			{Text: "[modified]", Align: gotui.AlignRight, FgColor: gotui.ColorRed},
+			{Text: "utf-8", Align: gotui.AlignRight, FgColor: gotui.ColorGreen},
+		}
+		v.Footer = "Footer"
+		v.FooterSegments = []gotui.TitleSegment{
+			{Text: "1:1", Align: gotui.AlignRight},
+		}
+	}
+	if v, err := g.SetView("v22", 105, 36, 125, 40); err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Long long long title"
+		v.TitleSegments = []gotui.TitleSegment{
+			{Text: "status", Align: gotui.AlignRight},
+		}
+		v.Footer = "Long long long long footer"
+		v.FooterAlign = gotui.AlignCenter
+	}
+
 	return nil
 }
@@ -0,0 +1,110 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/makyo/gotui"
+)
+
+func main() {
+	dir := "."
+	if len(os.Args) > 1 {
+		dir = os.Args[1]
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		log.Panicln(err)
+	}
+
+	g, err := gotui.NewGui(gotui.OutputNormal)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer g.Close()
+
+	g.Mouse = true
+
+	tree := gotui.NewTree("files", 0, 0, 0, 0, &gotui.TreeNode{Text: dir, Data: dir})
+	tree.Title = "arrows: navigate, space: toggle, ^C: exit"
+	tree.LoadChildren = loadDir
+	if err := tree.Expand(tree.Root); err != nil {
+		log.Panicln(err)
+	}
+	tree.OnChange = func(g *gotui.Gui, t *gotui.Tree) error {
+		v, err := g.View("status")
+		if err != nil {
+			return nil
+		}
+		v.Clear()
+		fmt.Fprint(v, t.Current().Data)
+		return nil
+	}
+
+	g.SetManagerFunc(func(g *gotui.Gui) error {
+		maxX, maxY := g.Size()
+		tree.SetPosition(0, 0, maxX-1, maxY-4)
+		if err := tree.Layout(g); err != nil {
+			return err
+		}
+		if v, err := g.View("files"); err == nil {
+			v.VScrollbar = true
+		}
+		if v, err := g.SetView("status", 0, maxY-3, maxX-1, maxY-1); err != nil {
+			if err != gotui.ErrUnknownView {
+				return err
+			}
+			v.Focusable = false
+			fmt.Fprint(v, dir)
+		}
+		if g.CurrentView() == nil {
+			if _, err := g.SetCurrentView("files"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err := g.SetKeybinding("", gotui.KeyCtrlC, gotui.ModNone, quit); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := g.MainLoop(); err != nil && err != gotui.ErrQuit {
+		log.Panicln(err)
+	}
+}
+
+// loadDir loads the entries of a directory the first time it is expanded.
+func loadDir(n *gotui.TreeNode) ([]*gotui.TreeNode, error) {
+	path := n.Data.(string)
+	infos, err := ioutil.ReadDir(path)
+	if err != nil {
+		// show the error instead of quitting
+		return []*gotui.TreeNode{{Text: err.Error(), Data: path, Leaf: true}}, nil
+	}
+
+	var nodes []*gotui.TreeNode
+	for _, fi := range infos {
+		text := fi.Name()
+		if fi.IsDir() {
+			text += "/"
+		}
+		nodes = append(nodes, &gotui.TreeNode{
+			Text: text,
+			Data: filepath.Join(path, fi.Name()),
+			Leaf: !fi.IsDir(),
+		})
+	}
+	return nodes, nil
+}
+
+func quit(g *gotui.Gui, v *gotui.View) error {
+	return gotui.ErrQuit
+}
@@ -5,7 +5,6 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -48,41 +47,6 @@ func (w *HelpWidget) Layout(g *gotui.Gui) error {
 	return nil
 }
 
-type StatusbarWidget struct {
-	name string
-	x, y int
-	w    int
-	val  float64
-}
-
-func NewStatusbarWidget(name string, x, y, w int) *StatusbarWidget {
-	return &StatusbarWidget{name: name, x: x, y: y, w: w}
-}
-
-func (w *StatusbarWidget) SetVal(val float64) error {
-	if val < 0 || val > 1 {
-		return errors.New("invalid value")
-	}
-	w.val = val
-	return nil
-}
-
-func (w *StatusbarWidget) Val() float64 {
-	return w.val
-}
-
-func (w *StatusbarWidget) Layout(g *gotui.Gui) error {
-	v, err := g.SetView(w.name, w.x, w.y, w.x+w.w, w.y+2)
-	if err != nil && err != gotui.ErrUnknownView {
-		return err
-	}
-	v.Clear()
-
-	rep := int(w.val * float64(w.w-1))
-	fmt.Fprint(v, strings.Repeat("▒", rep))
-	return nil
-}
-
 type ButtonWidget struct {
 	name    string
 	x, y    int
@@ -104,9 +68,18 @@ func (w *ButtonWidget) Layout(g *gotui.Gui) error {
 		if _, err := g.SetCurrentView(w.name); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gotui.KeyEnter, gotui.ModNone, w.handler); err != nil {
+		if err := v.SetKeybinding(gotui.KeyEnter, gotui.ModNone, w.handler); err != nil {
 			return err
 		}
+		v.OnMouse = func(g *gotui.Gui, v *gotui.View, ev *gotui.MouseEvent) error {
+			if ev.Button != gotui.MouseLeft || ev.Action != gotui.MouseActionPress {
+				return nil
+			}
+			if _, err := g.SetCurrentView(v.Name()); err != nil {
+				return err
+			}
+			return w.handler(g, v)
+		}
 		fmt.Fprint(v, w.label)
 	}
 	return nil
@@ -121,9 +94,11 @@ func main() {
 
 	g.Highlight = true
 	g.SelFgColor = gotui.ColorRed
+	g.Mouse = true
 
 	help := NewHelpWidget("help", 1, 1, helpText)
-	status := NewStatusbarWidget("status", 1, 7, 50)
+	status := gotui.NewProgressBar("status", 1, 7, 51, 9)
+	status.ShowPercent = true
 	butdown := NewButtonWidget("butdown", 52, 7, "DOWN", statusDown(status))
 	butup := NewButtonWidget("butup", 58, 7, "UP", statusUp(status))
 	g.SetManager(help, status, butdown, butup)
@@ -153,27 +128,24 @@ func toggleButton(g *gotui.Gui, v *gotui.View) error {
 	return err
 }
 
-func statusUp(status *StatusbarWidget) func(g *gotui.Gui, v *gotui.View) error {
+func statusUp(status *gotui.ProgressBar) func(g *gotui.Gui, v *gotui.View) error {
 	return func(g *gotui.Gui, v *gotui.View) error {
 		return statusSet(status, delta)
 	}
 }
 
-func statusDown(status *StatusbarWidget) func(g *gotui.Gui, v *gotui.View) error {
+func statusDown(status *gotui.ProgressBar) func(g *gotui.Gui, v *gotui.View) error {
 	return func(g *gotui.Gui, v *gotui.View) error {
 		return statusSet(status, -delta)
 	}
 }
 
-func statusSet(sw *StatusbarWidget, inc float64) error {
-	val := sw.Val() + inc
-	if val < 0 || val > 1 {
-		return nil
-	}
-	return sw.SetVal(val)
+func statusSet(status *gotui.ProgressBar, inc float64) error {
+	status.SetValue(status.Value() + inc)
+	return nil
 }
 
 const helpText = `KEYBINDINGS
 Tab: Move between buttons
-Enter: Push button
+Enter, click: Push button
 ^C: Exit`
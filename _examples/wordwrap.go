@@ -186,6 +186,7 @@ func layout(g *gotui.Gui) error {
 		v.WordWrap = true
 		v.IndentFirst = 2
 		v.IndentSubsequent = 10
+		v.Padding = gotui.Padding{Top: 1, Right: 2, Bottom: 1, Left: 2}
 		if _, err := g.SetCurrentView("main"); err != nil {
 			return err
 		}
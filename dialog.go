@@ -0,0 +1,343 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialog describes a modal dialog. While a dialog is open, it receives all
+// the keyboard and mouse events, so no other keybinding is triggered. TAB
+// and the arrow keys select a button, Enter activates the selected button
+// and Esc cancels the dialog.
+type Dialog struct {
+	// Title is the title of the dialog.
+	Title string
+
+	// Message is the text shown in the dialog. It is wrapped to the width
+	// of the dialog.
+	Message string
+
+	// Buttons are the labels of the buttons of the dialog. If it is empty,
+	// the dialog has an "OK" button.
+	Buttons []string
+
+	// If Input is true, the dialog has an editable line, initialized with
+	// Text.
+	Input bool
+
+	// Text is the initial content of the editable line.
+	Text string
+
+	// Width is the width of the dialog. If it is 0, the width is computed
+	// from its content.
+	Width int
+
+	// OnClose, if not nil, is called when the dialog is closed.
+	OnClose func(g *Gui, r DialogResult) error
+}
+
+// DialogResult describes how a dialog was closed.
+type DialogResult struct {
+	// Button is the index of the button that closed the dialog, or -1 if
+	// the dialog was canceled.
+	Button int
+
+	// Text is the content of the editable line of the dialog.
+	Text string
+}
+
+// dialog is an open dialog.
+type dialog struct {
+	Dialog
+	name     string
+	prev     *View // current view when the dialog was opened
+	selected int   // index of the selected button
+	result   chan DialogResult
+}
+
+// ShowDialog opens a modal dialog, centered on top of the existing views in
+// LayerDialog. The result is passed to the OnClose handler of the dialog and
+// sent to the returned channel, which can be used from other goroutines.
+// Dialogs can be nested, in which case the last one receives the events.
+func (g *Gui) ShowDialog(d Dialog) (<-chan DialogResult, error) {
+	if len(d.Buttons) == 0 {
+		d.Buttons = []string{"OK"}
+	}
+	g.dialogSeq++
+	dlg := &dialog{
+		Dialog: d,
+		name:   fmt.Sprintf("gotui.dialog%d", g.dialogSeq),
+		prev:   g.currentView,
+		result: make(chan DialogResult, 1),
+	}
+	g.dialogs = append(g.dialogs, dlg)
+	if err := g.layoutDialog(dlg); err != nil {
+		return nil, err
+	}
+	return dlg.result, nil
+}
+
+// Alert opens a dialog with the given message and an "OK" button. The
+// handler, if not nil, is called when the dialog is closed.
+func (g *Gui) Alert(title, message string, handler func(g *Gui) error) error {
+	_, err := g.ShowDialog(Dialog{
+		Title:   title,
+		Message: message,
+		OnClose: func(g *Gui, r DialogResult) error {
+			if handler == nil {
+				return nil
+			}
+			return handler(g)
+		},
+	})
+	return err
+}
+
+// Confirm opens a dialog with the given message and the buttons "OK" and
+// "Cancel". The handler, if not nil, is called when the dialog is closed,
+// with ok set to true if it was accepted.
+func (g *Gui) Confirm(title, message string, handler func(g *Gui, ok bool) error) error {
+	_, err := g.ShowDialog(Dialog{
+		Title:   title,
+		Message: message,
+		Buttons: []string{"OK", "Cancel"},
+		OnClose: func(g *Gui, r DialogResult) error {
+			if handler == nil {
+				return nil
+			}
+			return handler(g, r.Button == 0)
+		},
+	})
+	return err
+}
+
+// Prompt opens a dialog that asks the user for a line of text, initialized
+// with text. The handler, if not nil, is called when the dialog is closed,
+// with the entered text and ok set to true if it was accepted.
+func (g *Gui) Prompt(title, message, text string, handler func(g *Gui, text string, ok bool) error) error {
+	_, err := g.ShowDialog(Dialog{
+		Title:   title,
+		Message: message,
+		Buttons: []string{"OK", "Cancel"},
+		Input:   true,
+		Text:    text,
+		OnClose: func(g *Gui, r DialogResult) error {
+			if handler == nil {
+				return nil
+			}
+			return handler(g, r.Text, r.Button == 0)
+		},
+	})
+	return err
+}
+
+// activeDialog returns the dialog that receives the events, or nil if there
+// are no open dialogs.
+func (g *Gui) activeDialog() *dialog {
+	if len(g.dialogs) == 0 {
+		return nil
+	}
+	return g.dialogs[len(g.dialogs)-1]
+}
+
+// layoutDialogs places the open dialogs in the center of the screen.
+func (g *Gui) layoutDialogs() error {
+	for _, d := range g.dialogs {
+		if err := g.layoutDialog(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// layoutDialog places the views of the dialog in the center of the screen,
+// creating them if needed.
+func (g *Gui) layoutDialog(d *dialog) error {
+	maxX, maxY := g.Size()
+
+	// compute the size of the dialog, which has a frame and 1 column of
+	// padding on each side
+	bw := 2 * (len(d.Buttons) - 1)
+	for _, b := range d.Buttons {
+		bw += len([]rune(b)) + 4
+	}
+	w := d.Width
+	if w <= 0 {
+		w = bw + 4
+		if tw := len([]rune(d.Title)) + 8; tw > w {
+			w = tw
+		}
+		for _, l := range strings.Split(d.Message, "\n") {
+			if lw := len([]rune(l)) + 4; lw > w {
+				w = lw
+			}
+		}
+		if d.Input && w < 30 {
+			w = 30
+		}
+	}
+	if w > maxX-2 {
+		w = maxX - 2
+	}
+	if w < 10 {
+		w = 10
+	}
+	inner := w - 4
+
+	msgRows := 0
+	if d.Message != "" {
+		for _, l := range strings.Split(d.Message, "\n") {
+			if n := len([]rune(l)); n < inner {
+				msgRows++
+			} else {
+				msgRows += n/inner + 1
+			}
+		}
+		msgRows++
+	}
+	inputRow := msgRows
+	buttonRow := msgRows
+	if d.Input {
+		buttonRow += 2
+	}
+	h := buttonRow + 3
+
+	x0, y0 := (maxX-w)/2, (maxY-h)/2
+	x1, y1 := x0+w-1, y0+h-1
+
+	v, err := g.SetView(d.name, x0, y0, x1, y1)
+	if err != nil {
+		if err != ErrUnknownView {
+			return err
+		}
+		v.Title = d.Title
+		v.Wrap = true
+		v.Padding = Padding{Left: 1, Right: 1}
+		fmt.Fprint(v, d.Message)
+		if err := g.setFocus(v, true); err != nil {
+			return err
+		}
+	}
+	g.setDialogView(d, v)
+
+	if d.Input {
+		y := y0 + 1 + inputRow
+		v, err := g.SetView(d.name+".input", x0+1, y-1, x1-1, y+1)
+		if err != nil {
+			if err != ErrUnknownView {
+				return err
+			}
+			v.Frame = false
+			v.Editable = true
+			v.FgColor = g.FgColor | AttrUnderline
+			fmt.Fprint(v, d.Text)
+			v.setCursorPosition(len([]rune(d.Text)), 0)
+			if err := g.setFocus(v, true); err != nil {
+				return err
+			}
+		}
+		g.setDialogView(d, v)
+	}
+
+	x, y := x0+(w-bw)/2, y0+1+buttonRow
+	for i, b := range d.Buttons {
+		n := len([]rune(b)) + 4
+		v, err := g.SetView(fmt.Sprintf("%s.button%d", d.name, i), x-1, y-1, x+n, y+1)
+		if err != nil {
+			if err != ErrUnknownView {
+				return err
+			}
+			v.Frame = false
+			fmt.Fprintf(v, "[ %s ]", b)
+		}
+		g.setDialogView(d, v)
+		v.FgColor = g.FgColor
+		if i == d.selected {
+			v.FgColor |= AttrReverse
+		}
+		x += n + 2
+	}
+	return nil
+}
+
+// setDialogView configures a view that belongs to a dialog.
+func (g *Gui) setDialogView(d *dialog, v *View) {
+	v.Layer = LayerDialog
+	v.Group = d.name
+	v.Focusable = false
+}
+
+// closeDialog closes the dialog, restoring the previous current view, and
+// reports the result.
+func (g *Gui) closeDialog(d *dialog, button int) error {
+	r := DialogResult{Button: button}
+	if v, err := g.View(d.name + ".input"); err == nil {
+		r.Text = strings.TrimRight(v.Buffer(), "\n")
+	}
+
+	for i, dlg := range g.dialogs {
+		if dlg == d {
+			g.dialogs = append(g.dialogs[:i], g.dialogs[i+1:]...)
+			break
+		}
+	}
+	if err := g.DeleteGroup(d.name); err != nil && err != ErrUnknownView {
+		return err
+	}
+
+	if d.prev == nil || g.hasView(d.prev) {
+		if err := g.setFocus(d.prev, false); err != nil {
+			return err
+		}
+	}
+
+	d.result <- r
+	if d.OnClose != nil {
+		return d.OnClose(g, r)
+	}
+	return nil
+}
+
+// onDialogKey manages the key-press events while a dialog is open.
+func (g *Gui) onDialogKey(d *dialog, ev *event) error {
+	switch {
+	case ev.key == KeyEsc:
+		return g.closeDialog(d, -1)
+	case ev.key == KeyEnter:
+		return g.closeDialog(d, d.selected)
+	case ev.key == KeyTab && ev.mod&ModShift != 0,
+		ev.key == KeyArrowLeft && !d.Input:
+		d.selected = (d.selected + len(d.Buttons) - 1) % len(d.Buttons)
+	case ev.key == KeyTab,
+		ev.key == KeyArrowRight && !d.Input:
+		d.selected = (d.selected + 1) % len(d.Buttons)
+	case d.Input:
+		v, err := g.View(d.name + ".input")
+		if err == nil && v.Editor != nil {
+			v.Editor.Edit(v, ev.key, ev.ch, ev.mod)
+		}
+	}
+	return g.layoutDialog(d)
+}
+
+// onDialogMouse manages the mouse events while a dialog is open. Clicking a
+// button activates it.
+func (g *Gui) onDialogMouse(d *dialog, ev *event) error {
+	if ev.key != MouseLeft || ev.mod&ModMotion != 0 {
+		return nil
+	}
+	v, err := g.ViewByPosition(ev.mouseX, ev.mouseY)
+	if err != nil || v.Group != d.name {
+		return nil
+	}
+	for i := range d.Buttons {
+		if v.name == fmt.Sprintf("%s.button%d", d.name, i) {
+			return g.closeDialog(d, i)
+		}
+	}
+	return nil
+}
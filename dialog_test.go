@@ -0,0 +1,193 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"strings"
+	"testing"
+)
+
+// newDialogGui returns a GUI with a "main" view. If focus is true, it is
+// the current view.
+func newDialogGui(t *testing.T, focus bool) *Gui {
+	g := &Gui{maxX: 80, maxY: 24}
+	if _, err := g.SetView("main", 0, 0, 79, 23); err != ErrUnknownView {
+		t.Fatal(err)
+	}
+	if focus {
+		if _, err := g.SetCurrentView("main"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+// dialogViews returns the number of views that belong to dialogs.
+func dialogViews(g *Gui) int {
+	n := 0
+	for _, v := range g.Views() {
+		if strings.HasPrefix(v.Name(), "gotui.dialog") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDialogClose(t *testing.T) {
+	key := func(k Key) *event {
+		return &event{typ: eventKey, key: k}
+	}
+	char := func(ch rune) *event {
+		return &event{typ: eventKey, ch: ch}
+	}
+
+	tests := []struct {
+		name    string
+		focus   bool
+		dialog  Dialog
+		events  []*event
+		result  DialogResult
+		current string
+	}{
+		{
+			"alert canceled with Esc",
+			true,
+			Dialog{Title: "Alert", Message: "message"},
+			[]*event{key(KeyEsc)},
+			DialogResult{Button: -1},
+			"main",
+		},
+		{
+			"alert accepted with Enter",
+			true,
+			Dialog{Title: "Alert", Message: "message"},
+			[]*event{key(KeyEnter)},
+			DialogResult{Button: 0},
+			"main",
+		},
+		{
+			"button selected with Tab",
+			true,
+			Dialog{Message: "message", Buttons: []string{"OK", "Cancel"}},
+			[]*event{key(KeyTab), key(KeyEnter)},
+			DialogResult{Button: 1},
+			"main",
+		},
+		{
+			"prompt canceled with Esc",
+			true,
+			Dialog{Message: "name", Input: true, Text: "ab", Buttons: []string{"OK", "Cancel"}},
+			[]*event{char('c'), key(KeyEsc)},
+			DialogResult{Button: -1, Text: "abc"},
+			"main",
+		},
+		{
+			"no previous current view",
+			false,
+			Dialog{Message: "message"},
+			[]*event{key(KeyEsc)},
+			DialogResult{Button: -1},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		g := newDialogGui(t, tt.focus)
+		var got *DialogResult
+		tt.dialog.OnClose = func(g *Gui, r DialogResult) error {
+			got = &r
+			return nil
+		}
+		ch, err := g.ShowDialog(tt.dialog)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !strings.HasPrefix(currentName(g), "gotui.dialog") {
+			t.Errorf("%s: dialog does not have the focus, current view is %q", tt.name, currentName(g))
+		}
+
+		for _, ev := range tt.events {
+			if err := g.onKey(ev); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+		}
+
+		if got == nil {
+			t.Errorf("%s: OnClose not called", tt.name)
+		} else if *got != tt.result {
+			t.Errorf("%s: got result %+v, want %+v", tt.name, *got, tt.result)
+		}
+		select {
+		case r := <-ch:
+			if r != tt.result {
+				t.Errorf("%s: got result %+v from the channel, want %+v", tt.name, r, tt.result)
+			}
+		default:
+			t.Errorf("%s: no result sent to the channel", tt.name)
+		}
+		if n := dialogViews(g); n != 0 {
+			t.Errorf("%s: %d dialog views left", tt.name, n)
+		}
+		if g.activeDialog() != nil {
+			t.Errorf("%s: dialog still open", tt.name)
+		}
+		if cur := currentName(g); cur != tt.current {
+			t.Errorf("%s: current view is %q, want %q", tt.name, cur, tt.current)
+		}
+	}
+}
+
+func TestDialogBlocksKeybindings(t *testing.T) {
+	g := newDialogGui(t, true)
+	called := false
+	if err := g.SetKeybinding("", KeyEsc, ModNone, func(g *Gui, v *View) error {
+		called = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Alert("Alert", "message", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.onKey(&event{typ: eventKey, key: KeyEsc}); err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Error("keybinding triggered while the dialog was open")
+	}
+	if err := g.onKey(&event{typ: eventKey, key: KeyEsc}); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Error("keybinding not triggered after closing the dialog")
+	}
+}
+
+func TestNestedDialogs(t *testing.T) {
+	g := newDialogGui(t, true)
+	if err := g.Alert("first", "first", nil); err != nil {
+		t.Fatal(err)
+	}
+	first := currentName(g)
+	if err := g.Alert("second", "second", nil); err != nil {
+		t.Fatal(err)
+	}
+	if cur := currentName(g); cur == first {
+		t.Fatalf("second dialog does not have the focus")
+	}
+
+	if err := g.onKey(&event{typ: eventKey, key: KeyEsc}); err != nil {
+		t.Fatal(err)
+	}
+	if cur := currentName(g); cur != first {
+		t.Errorf("after closing the second dialog, current view is %q, want %q", cur, first)
+	}
+	if err := g.onKey(&event{typ: eventKey, key: KeyEsc}); err != nil {
+		t.Fatal(err)
+	}
+	if cur := currentName(g); cur != "main" {
+		t.Errorf("after closing the first dialog, current view is %q, want %q", cur, "main")
+	}
+}
@@ -58,6 +58,37 @@ Configure keybindings:
 		// handle error
 	}
 
+Special keys combined with Shift, Ctrl or Meta, like Ctrl+ArrowLeft or
+Shift+Tab, are reported with the corresponding modifiers:
+
+	if err := g.SetKeybinding("viewname", gotui.KeyArrowLeft, gotui.ModCtrl, wordLeft); err != nil {
+		// handle error
+	}
+
+Terminals supporting the kitty keyboard protocol or xterm's modifyOtherKeys
+can report even more combinations, like Ctrl+Enter, if enabled with:
+
+	g.ExtendedKeys = true
+
+Shifted letters are then reported as upper case runes without modifiers,
+like termbox does, but other runes keep ModShift, since the character they
+produce depends on the keyboard layout.
+
+Keybindings can also be restricted to an input mode, which allows to build
+modal interfaces:
+
+	if err := g.SetModeKeybinding(gotui.ModeNormal, "viewname", 'i', gotui.ModNone, insertMode); err != nil {
+		// handle error
+	}
+
+	func insertMode(g *gotui.Gui, v *gotui.View) error {
+		return g.SetMode(gotui.ModeInsert)
+	}
+
+Keybindings set with SetKeybinding apply to all modes. The Editor of the
+current view only receives keys in the modes that allow edition (see
+*Gui.SetModeEditable).
+
 gotui implements full mouse support that can be enabled with:
 
 	g.Mouse = true
@@ -68,6 +99,156 @@ Mouse events are handled like any other keybinding:
 		// handle error
 	}
 
+Mouse bindings receive the details of every mouse event on a view, like the
+position of the pointer, the action (press, release or drag), the number of
+consecutive clicks and the modifiers:
+
+	if err := g.SetMouseBinding("viewname", onMouse); err != nil {
+		// handle error
+	}
+
+	func onMouse(g *gotui.Gui, v *gotui.View, ev *gotui.MouseEvent) error {
+		if ev.Action == gotui.MouseActionPress && ev.Clicks == 2 {
+			// handle double click at ev.ViewX, ev.ViewY
+		}
+		return nil
+	}
+
+A view can capture the mouse, usually when a drag starts, to keep receiving
+the events that happen outside of its bounds until the button is released:
+
+	if err := g.CaptureMouse("viewname"); err != nil {
+		// handle error
+	}
+
+If MouseMotion is true, motion is reported even when no button is pressed
+and views receive MouseActionEnter and MouseActionLeave events, which can be
+used to implement hover effects:
+
+	g.MouseMotion = true
+
+Views can also carry their own keybindings and event handlers, which do not
+depend on the name of the view and are deleted with it. This allows to build
+self-contained widgets:
+
+	v.SetKeybinding(gotui.KeyEnter, gotui.ModNone, submit)
+	v.OnMouse = onMouse
+	v.OnResize = func(g *gotui.Gui, v *gotui.View, width, height int) error {
+		// redraw the content for the new size
+		return nil
+	}
+	v.OnChange = func(g *gotui.Gui, v *gotui.View) error {
+		// the content has been modified
+		return nil
+	}
+
+Views are stacked by Layer and ZIndex, so popups stay on top of the views
+created later by the managers. Views sharing a Group can be raised, moved and
+deleted together:
+
+	v.Layer = gotui.LayerPopup
+	v.Group = "dialog"
+	// ...
+	g.SetGroupOnTop("dialog")
+
+FocusNext and FocusPrev move the focus through the visible views, ordered
+by TabIndex, skipping those with Focusable set to false. OnFocus and OnBlur
+are called when a view gains or loses the focus, and deleting the current
+view gives the focus back to the previous one:
+
+	v.OnFocus = func(g *gotui.Gui, v *gotui.View) error {
+		g.Cursor = v.Editable
+		return nil
+	}
+
+Modal dialogs are shown on top of the other views and receive all the input
+until they are closed. Alert, Confirm and Prompt cover the common cases and
+ShowDialog allows custom buttons:
+
+	g.Confirm("Quit", "Are you sure?", func(g *gotui.Gui, ok bool) error {
+		if ok {
+			return gotui.ErrQuit
+		}
+		return nil
+	})
+
+gotui includes reusable widgets, which are managers built on views that can
+be placed by hand or with the layout package. List shows a list of items
+that can be navigated, selected and filtered:
+
+	list := gotui.NewList("files", 0, 0, 30, 20, names)
+	list.Filterable = true
+	list.OnSelect = func(g *gotui.Gui, l *gotui.List, index int) error {
+		// open l.Items()[index]
+		return nil
+	}
+	g.SetManager(list)
+
+Table shows rows of cells with per-cell colors under a fixed header. Columns
+can have a fixed width, fit their content or share the remaining space, and
+the rows can be sorted by any column:
+
+	table := gotui.NewTable("processes", 0, 0, 60, 20, []gotui.TableColumn{
+		{Title: "PID", Align: gotui.AlignRight},
+		{Title: "COMMAND", Sizing: gotui.ColumnFlex},
+	})
+	table.SetRows(rows)
+	table.SortBy(0, false)
+
+Tree shows a hierarchy of nodes that can be expanded and collapsed. Children
+can be loaded when a node is expanded for the first time:
+
+	tree := gotui.NewTree("files", 0, 0, 40, 20, &gotui.TreeNode{Text: "/"})
+	tree.LoadChildren = func(n *gotui.TreeNode) ([]*gotui.TreeNode, error) {
+		// read the children of n
+	}
+
+TextInput edits a single line of text, with a placeholder, an optional mask,
+validation and a history recalled with the arrow keys:
+
+	input := gotui.NewTextInput("name", 0, 0, 30, 2)
+	input.Placeholder = "your name"
+	input.OnSubmit = func(g *gotui.Gui, t *gotui.TextInput, text string) error {
+		// use text
+		return nil
+	}
+
+Form lays out labeled inputs, checkboxes, radio groups, dropdowns and
+buttons, moves the focus between them with Tab and validates them before
+passing their values to OnSubmit:
+
+	form := gotui.NewForm("setup", 0, 0, 50, 12)
+	form.AddInput("host", "Host:")
+	form.AddCheckbox("tls", "Use TLS:", true)
+	form.AddDropdown("region", "Region:", []string{"eu", "us"}, 0)
+	form.AddButton("OK", form.Submit)
+	form.OnSubmit = func(g *gotui.Gui, f *gotui.Form, values map[string]interface{}) error {
+		// use values["host"].(string) and values["tls"].(bool)
+		return nil
+	}
+
+ProgressBar, Gauge and Spinner show the progress of tasks. Bars are drawn
+with eighths of a cell, or with ASCII characters when Gui.ASCII is true.
+Indeterminate bars, spinners and ETAs depend on the time, so the GUI must be
+redrawn periodically to animate them:
+
+	bar := gotui.NewProgressBar("download", 0, 0, 40, 2)
+	bar.ShowPercent = true
+	bar.ShowETA = true
+	go func() {
+		for range time.Tick(100 * time.Millisecond) {
+			g.Update(func(g *gotui.Gui) error {
+				bar.SetValue(downloaded())
+				return nil
+			})
+		}
+	}()
+
+Views can be hidden without deleting them, so they keep their content,
+cursor and origin. Hidden views are not drawn and do not receive events:
+
+	v.Visible = false
+
 IMPORTANT: Views can only be created, destroyed or updated in three ways: from
 the Layout function within managers, from keybinding callbacks or via
 *Gui.Update(). The reason for this is that it allows gotui to be
@@ -91,9 +272,10 @@ and customized creating a new Editor and assigning it to *View.Editor:
 		Edit(v *View, key Key, ch rune, mod Modifier)
 	}
 
-DefaultEditor can be taken as example to create your own custom Editor:
+The editing function used by DefaultEditor can be taken as example to create
+your own custom Editor:
 
-	var DefaultEditor Editor = EditorFunc(simpleEditor)
+	var MyEditor Editor = EditorFunc(simpleEditor)
 
 	func simpleEditor(v *View, key Key, ch rune, mod Modifier) {
 		switch {
@@ -107,6 +289,57 @@ DefaultEditor can be taken as example to create your own custom Editor:
 		}
 	}
 
+If BracketedPaste is true, pasted text is delivered to the Editor as a whole,
+without triggering keybindings. Editors can handle it in a single operation
+by implementing the PasteEditor interface, as DefaultEditor does:
+
+	type PasteEditor interface {
+		Paste(v *View, text string)
+	}
+
+Frames:
+
+The frames of adjacent views with the same layer and z-index are joined with
+the proper junctions, while popups are drawn over the views below them. Their
+runes can be configured per view or for the whole GUI, and the current view
+can use a different style so it can be told apart without relying on colors:
+
+	g.FrameStyle = gotui.FrameRounded
+	g.SelFrameStyle = gotui.FrameHeavy
+	v.FrameStyle = gotui.FrameStyle{HideLeft: true, HideRight: true}
+
+Besides the Title, frames can show a Footer and additional title segments,
+each one with its own alignment and colors:
+
+	v.Title = "main.go"
+	v.TitleSegments = []gotui.TitleSegment{
+		{Text: "[modified]", Align: gotui.AlignRight, FgColor: gotui.ColorRed},
+	}
+	v.Footer = "1:1"
+	v.FooterAlign = gotui.AlignRight
+
+Padding leaves some space between the frame and the content of a view. It is
+taken into account when wrapping, moving the cursor and translating mouse
+coordinates:
+
+	v.Padding = gotui.Padding{Top: 1, Right: 2, Bottom: 1, Left: 2}
+
+Views can show scrollbars when their content does not fit. If the mouse is
+enabled, clicking the track scrolls a page, the thumb can be dragged and the
+wheel scrolls the view:
+
+	v.VScrollbar = true
+	v.HScrollbar = true
+
+Views can be scrolled with ScrollUp, ScrollDown, PageUp, PageDown, ScrollTo,
+ScrollToTop and ScrollToBottom, which take wrapped lines into account.
+SetScrollKeybindings sets the usual keybindings to do it, including the
+mouse wheel:
+
+	if err := g.SetScrollKeybindings("log"); err != nil {
+		// handle error
+	}
+
 Colored text:
 
 Views allow to add colored text using ANSI colors. For example:
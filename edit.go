@@ -25,8 +25,30 @@ func (f EditorFunc) Edit(v *View, key Key, ch rune, mod Modifier) {
 	f(v, key, ch, mod)
 }
 
+// PasteEditor is implemented by the editors that handle pasted text in a
+// single operation. If the Editor of a view does not implement it, pasted
+// text is passed to Edit rune by rune. Bracketed paste must be enabled
+// (see Gui.BracketedPaste) for the text to be reported as pasted.
+type PasteEditor interface {
+	Paste(v *View, text string)
+}
+
 // DefaultEditor is the default editor.
-var DefaultEditor Editor = EditorFunc(simpleEditor)
+var DefaultEditor Editor = defaultEditor{}
+
+// defaultEditor is used as the default gotui editor. It edits like
+// simpleEditor and inserts pasted text in a single operation.
+type defaultEditor struct{}
+
+// Edit calls simpleEditor(v, key, ch, mod)
+func (defaultEditor) Edit(v *View, key Key, ch rune, mod Modifier) {
+	simpleEditor(v, key, ch, mod)
+}
+
+// Paste calls v.EditPaste(text)
+func (defaultEditor) Paste(v *View, text string) {
+	v.EditPaste(text)
+}
 
 // simpleEditor is used as the default gotui editor.
 func simpleEditor(v *View, key Key, ch rune, mod Modifier) {
@@ -107,6 +129,17 @@ func (v *View) EditDelete(back bool) {
 	}
 }
 
+// EditPaste inserts text at the cursor position in a single operation and
+// moves the cursor to the end of the inserted text.
+func (v *View) EditPaste(text string) {
+	x, y, err := v.realPosition(v.cx, v.cy)
+	if err != nil {
+		return
+	}
+	x, y = v.insertText(x, y, text)
+	v.setCursorPosition(x, y)
+}
+
 // EditNewLine inserts a new line under the cursor.
 func (v *View) EditNewLine() {
 	v.breakLine(v.cx, v.cy)
@@ -232,12 +265,99 @@ func (v *View) MoveCursor(dx, dy int, writeMode bool) {
 	}
 }
 
+// setCursorPosition moves the cursor to the position (x, y) of the internal
+// buffer, displacing the origin if necessary.
+func (v *View) setCursorPosition(x, y int) {
+	maxX, maxY := v.Size()
+	if maxX <= 0 || maxY <= 0 {
+		return
+	}
+	if v.tainted {
+		v.updateViewLines(maxX)
+	}
+
+	// find the view line containing the position
+	vy, col := len(v.viewLines), x
+	for i, vline := range v.viewLines {
+		if vline.linesY > y {
+			break
+		}
+		if vline.linesY == y && vline.linesX <= x {
+			vy, col = i, x-vline.linesX
+		}
+	}
+	if v.Wrap && col >= maxX {
+		vy, col = vy+1, 0
+	}
+
+	if vy < v.oy {
+		v.oy = vy
+	} else if vy >= v.oy+maxY {
+		v.oy = vy - maxY + 1
+	}
+	v.cy = vy - v.oy
+
+	if !v.Wrap {
+		if col < v.ox {
+			v.ox = col
+		} else if col >= v.ox+maxX {
+			v.ox = col - maxX + 1
+		}
+	}
+	v.cx = col - v.ox
+}
+
+// insertText inserts text into the view's internal buffer, at the position
+// (x, y) of the buffer. It returns the position of the end of the inserted
+// text.
+func (v *View) insertText(x, y int, text string) (int, int) {
+	v.tainted = true
+	v.changed = true
+
+	if y >= len(v.lines) {
+		s := make([][]cell, y-len(v.lines)+1)
+		v.lines = append(v.lines, s...)
+	}
+	if x > len(v.lines[y]) {
+		s := make([]cell, x-len(v.lines[y]))
+		v.lines[y] = append(v.lines[y], s...)
+	}
+
+	tail := make([]cell, len(v.lines[y][x:]))
+	copy(tail, v.lines[y][x:])
+
+	var inserted [][]cell
+	cur := v.lines[y][:x]
+	for _, ch := range text {
+		if ch == '\n' {
+			inserted = append(inserted, cur)
+			cur = nil
+			continue
+		}
+		cur = append(cur, cell{
+			fgColor: v.FgColor,
+			bgColor: v.BgColor,
+			chr:     ch,
+		})
+	}
+	ex, ey := len(cur), y+len(inserted)
+	inserted = append(inserted, append(cur, tail...))
+
+	lines := make([][]cell, 0, len(v.lines)+len(inserted)-1)
+	lines = append(lines, v.lines[:y]...)
+	lines = append(lines, inserted...)
+	lines = append(lines, v.lines[y+1:]...)
+	v.lines = lines
+	return ex, ey
+}
+
 // writeRune writes a rune into the view's internal buffer, at the
 // position corresponding to the point (x, y). The length of the internal
 // buffer is increased if the point is out of bounds. Overwrite mode is
 // governed by the value of View.overwrite.
 func (v *View) writeRune(x, y int, ch rune) error {
 	v.tainted = true
+	v.changed = true
 
 	x, y, err := v.realPosition(x, y)
 	if err != nil {
@@ -279,6 +399,7 @@ func (v *View) writeRune(x, y int, ch rune) error {
 // position corresponding to the point (x, y).
 func (v *View) deleteRune(x, y int) error {
 	v.tainted = true
+	v.changed = true
 
 	x, y, err := v.realPosition(x, y)
 	if err != nil {
@@ -295,6 +416,7 @@ func (v *View) deleteRune(x, y int) error {
 // mergeLines merges the lines "y" and "y+1" if possible.
 func (v *View) mergeLines(y int) error {
 	v.tainted = true
+	v.changed = true
 
 	_, y, err := v.realPosition(0, y)
 	if err != nil {
@@ -316,6 +438,7 @@ func (v *View) mergeLines(y int) error {
 // to the point (x, y).
 func (v *View) breakLine(x, y int) error {
 	v.tainted = true
+	v.changed = true
 
 	x, y, err := v.realPosition(x, y)
 	if err != nil {
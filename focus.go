@@ -0,0 +1,120 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import "sort"
+
+// maxFocusHistory is the maximum number of views remembered by the focus
+// history.
+const maxFocusHistory = 64
+
+// FocusNext gives the focus to the next view of the focus ring, which is
+// made of the visible views with Focusable set to true, ordered by TabIndex
+// and, for the same TabIndex, by creation order.
+func (g *Gui) FocusNext() error {
+	return g.focusRingStep(1)
+}
+
+// FocusPrev gives the focus to the previous view of the focus ring.
+func (g *Gui) FocusPrev() error {
+	return g.focusRingStep(-1)
+}
+
+// RestoreFocus gives the focus back to the last view that had it before the
+// current one, skipping the views that have been deleted, are hidden or are
+// not focusable. It is called automatically when the current view is
+// deleted, so closing a popup returns the focus to the previous view.
+func (g *Gui) RestoreFocus() error {
+	for len(g.focusHistory) > 0 {
+		n := len(g.focusHistory) - 1
+		v := g.focusHistory[n]
+		g.focusHistory = g.focusHistory[:n]
+		if v != g.currentView && v.Visible && v.Focusable && g.hasView(v) {
+			return g.setFocus(v, false)
+		}
+	}
+	return nil
+}
+
+// focusRingStep moves the focus through the focus ring by the given number
+// of views.
+func (g *Gui) focusRingStep(step int) error {
+	var ring []*View
+	for _, v := range g.views {
+		if v.Visible && v.Focusable {
+			ring = append(ring, v)
+		}
+	}
+	if len(ring) == 0 {
+		return nil
+	}
+	sort.SliceStable(ring, func(i, j int) bool {
+		if ring[i].TabIndex != ring[j].TabIndex {
+			return ring[i].TabIndex < ring[j].TabIndex
+		}
+		return ring[i].seq < ring[j].seq
+	})
+
+	next := 0
+	if step < 0 {
+		next = len(ring) - 1
+	}
+	for i, v := range ring {
+		if v == g.currentView {
+			next = (i + step + len(ring)) % len(ring)
+			break
+		}
+	}
+	return g.setFocus(ring[next], true)
+}
+
+// setFocus makes v the current view, calling the OnBlur handler of the
+// previous current view and the OnFocus handler of v. If remember is true,
+// the previous current view is added to the focus history.
+func (g *Gui) setFocus(v *View, remember bool) error {
+	prev := g.currentView
+	if v == prev {
+		return nil
+	}
+	if prev != nil && remember {
+		g.forgetFocus(prev)
+		g.focusHistory = append(g.focusHistory, prev)
+		if len(g.focusHistory) > maxFocusHistory {
+			g.focusHistory = g.focusHistory[1:]
+		}
+	}
+	g.currentView = v
+
+	if prev != nil && prev.OnBlur != nil {
+		if err := prev.OnBlur(g, prev); err != nil {
+			return err
+		}
+	}
+	if v != nil && v.OnFocus != nil {
+		return v.OnFocus(g, v)
+	}
+	return nil
+}
+
+// forgetFocus removes the view from the focus history.
+func (g *Gui) forgetFocus(v *View) {
+	s := g.focusHistory[:0]
+	for _, hv := range g.focusHistory {
+		if hv != v {
+			s = append(s, hv)
+		}
+	}
+	g.focusHistory = s
+}
+
+// hasView returns if the view belongs to the GUI.
+func (g *Gui) hasView(v *View) bool {
+	for _, gv := range g.views {
+		if gv == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newFocusGui returns a GUI with a view for each of the given names.
+func newFocusGui(t *testing.T, names ...string) *Gui {
+	g := &Gui{maxX: 80, maxY: 24}
+	for i, name := range names {
+		if _, err := g.SetView(name, i*10, 0, i*10+5, 5); err != ErrUnknownView {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+// currentName returns the name of the current view, or "" if there is none.
+func currentName(g *Gui) string {
+	if v := g.CurrentView(); v != nil {
+		return v.Name()
+	}
+	return ""
+}
+
+func TestFocusRing(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(g *Gui)
+		current string
+		step    int
+		want    []string
+	}{
+		{"creation order", nil, "", 1, []string{"a", "b", "c", "d", "a"}},
+		{"creation order backwards", nil, "", -1, []string{"d", "c", "b", "a", "d"}},
+		{"from the current view", nil, "c", 1, []string{"d", "a", "b"}},
+		{"hidden view", func(g *Gui) {
+			mustView(g, "b").Visible = false
+		}, "a", 1, []string{"c", "d", "a", "c"}},
+		{"hidden view backwards", func(g *Gui) {
+			mustView(g, "c").Visible = false
+		}, "d", -1, []string{"b", "a", "d", "b"}},
+		{"not focusable view", func(g *Gui) {
+			mustView(g, "a").Focusable = false
+			mustView(g, "d").Focusable = false
+		}, "", 1, []string{"b", "c", "b"}},
+		{"tab index", func(g *Gui) {
+			mustView(g, "a").TabIndex = 2
+			mustView(g, "c").TabIndex = -1
+		}, "", 1, []string{"c", "b", "d", "a", "c"}},
+		{"hidden current view", func(g *Gui) {
+			mustView(g, "b").Visible = false
+		}, "b", 1, []string{"a", "c"}},
+		{"no focusable views", func(g *Gui) {
+			for _, v := range g.Views() {
+				v.Focusable = false
+			}
+		}, "", 1, []string{"", ""}},
+	}
+	for _, tt := range tests {
+		g := newFocusGui(t, "a", "b", "c", "d")
+		if tt.setup != nil {
+			tt.setup(g)
+		}
+		if tt.current != "" {
+			g.currentView = mustView(g, tt.current)
+		}
+		for i, want := range tt.want {
+			var err error
+			if tt.step > 0 {
+				err = g.FocusNext()
+			} else {
+				err = g.FocusPrev()
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if got := currentName(g); got != want {
+				t.Errorf("%s: step %d: got %q, want %q", tt.name, i, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestFocusHandlers(t *testing.T) {
+	g := newFocusGui(t, "a", "b")
+	var calls []string
+	for _, v := range g.Views() {
+		v.OnFocus = func(g *Gui, v *View) error {
+			calls = append(calls, "focus "+v.Name())
+			return nil
+		}
+		v.OnBlur = func(g *Gui, v *View) error {
+			calls = append(calls, "blur "+v.Name())
+			return nil
+		}
+	}
+	g.FocusNext()
+	g.FocusNext()
+	g.FocusNext()
+
+	want := []string{"focus a", "blur a", "focus b", "blur b", "focus a"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %v, want %v", calls, want)
+	}
+}
+
+func TestRestoreFocus(t *testing.T) {
+	tests := []struct {
+		name   string
+		focus  []string
+		setup  func(g *Gui)
+		delete string
+		want   string
+	}{
+		{"previous view", []string{"a", "b", "c"}, nil, "c", "b"},
+		{"deleted previous view", []string{"a", "b", "c"}, func(g *Gui) {
+			g.DeleteView("b")
+		}, "c", "a"},
+		{"hidden previous view", []string{"a", "b", "c"}, func(g *Gui) {
+			mustView(g, "b").Visible = false
+		}, "c", "a"},
+		{"not focusable previous view", []string{"a", "b", "c"}, func(g *Gui) {
+			mustView(g, "b").Focusable = false
+		}, "c", "a"},
+		{"repeated views", []string{"a", "b", "a", "c"}, nil, "c", "a"},
+		{"not the current view", []string{"a", "b", "c"}, nil, "b", "c"},
+		{"empty history", []string{"a"}, nil, "a", ""},
+		{"whole history deleted", []string{"a", "b", "c"}, func(g *Gui) {
+			g.DeleteView("a")
+			g.DeleteView("b")
+		}, "c", ""},
+	}
+	for _, tt := range tests {
+		g := newFocusGui(t, "a", "b", "c")
+		for _, name := range tt.focus {
+			if _, err := g.SetCurrentView(name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tt.setup != nil {
+			tt.setup(g)
+		}
+		if err := g.DeleteView(tt.delete); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := currentName(g); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRestoreFocusTwice(t *testing.T) {
+	g := newFocusGui(t, "a", "b", "c")
+	for _, name := range []string{"a", "b", "c"} {
+		g.SetCurrentView(name)
+	}
+	g.DeleteView("c")
+	g.DeleteView("b")
+	if got := currentName(g); got != "a" {
+		t.Errorf("got %q, want %q", got, "a")
+	}
+}
+
+// mustView returns the view with the given name, which must exist.
+func mustView(g *Gui, name string) *View {
+	v, err := g.View(name)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,644 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formFieldKind is the type of a form field.
+type formFieldKind int
+
+const (
+	formInput formFieldKind = iota
+	formCheckbox
+	formRadio
+	formDropdown
+)
+
+// formField is a field of a Form.
+type formField struct {
+	kind     formFieldKind
+	key      string
+	label    string
+	input    *TextInput
+	checked  bool
+	options  []string
+	selected int
+}
+
+// formButton is a button of a Form.
+type formButton struct {
+	label   string
+	handler func(g *Gui) error
+}
+
+// Form is a widget that lays out labeled fields, one per row, with a row of
+// buttons at the bottom. Fields can be text inputs, checkboxes, radio
+// groups and dropdowns. Tab and Shift-Tab move the focus between the fields
+// and the buttons, Enter submits the form and Esc cancels it, if InputEsc is
+// enabled in the GUI. The space key toggles checkboxes, opens dropdowns and
+// activates buttons, and the arrow keys change the option of radio groups
+// and dropdowns. All the views of the form share its name as Group, so they
+// can be moved, hidden or deleted together.
+type Form struct {
+	widget
+
+	// Title is the title of the view of the form.
+	Title string
+
+	// Validate, if not nil, is called with the values of the form when it
+	// is submitted, after validating its inputs. If it returns an error,
+	// the error is shown and the form is not submitted.
+	Validate func(values map[string]interface{}) error
+
+	// OnSubmit, if not nil, is called with the values of the form when it
+	// is submitted and it is valid. See Values.
+	OnSubmit func(g *Gui, f *Form, values map[string]interface{}) error
+
+	// OnCancel, if not nil, is called when the form is canceled.
+	OnCancel func(g *Gui, f *Form) error
+
+	fields  []*formField
+	buttons []*formButton
+	err     error // error shown in the form
+
+	popup      *List      // list of the open dropdown
+	popupField *formField // field of the open dropdown
+}
+
+// NewForm returns a new Form with the given name and position.
+func NewForm(name string, x0, y0, x1, y1 int) *Form {
+	return &Form{widget: widget{name: name, x0: x0, y0: y0, x1: x1, y1: y1}}
+}
+
+// AddInput adds a text input with the given key and label, and returns it
+// so it can be configured. Its validator is checked before submitting the
+// form. Enter and Esc submit and cancel the form, so the OnSubmit, OnCancel
+// and History of the input are not used.
+func (f *Form) AddInput(key, label string) *TextInput {
+	input := NewTextInput(f.name+"."+key, 0, 0, 0, 0)
+	f.fields = append(f.fields, &formField{kind: formInput, key: key, label: label, input: input})
+	return input
+}
+
+// AddCheckbox adds a checkbox with the given key, label and initial state.
+func (f *Form) AddCheckbox(key, label string, checked bool) {
+	f.fields = append(f.fields, &formField{kind: formCheckbox, key: key, label: label, checked: checked})
+}
+
+// AddRadio adds a group of radio buttons with the given key, label and
+// options, one per row. selected is the index of the selected option, or
+// -1 if none.
+func (f *Form) AddRadio(key, label string, options []string, selected int) {
+	f.fields = append(f.fields, &formField{kind: formRadio, key: key, label: label, options: options, selected: selected})
+}
+
+// AddDropdown adds a dropdown with the given key, label and options, which
+// are shown in a list when it is opened. selected is the index of the
+// selected option, or -1 if none.
+func (f *Form) AddDropdown(key, label string, options []string, selected int) {
+	f.fields = append(f.fields, &formField{kind: formDropdown, key: key, label: label, options: options, selected: selected})
+}
+
+// AddButton adds a button with the given label. Submit and Cancel can be
+// used as handlers.
+func (f *Form) AddButton(label string, handler func(g *Gui) error) {
+	f.buttons = append(f.buttons, &formButton{label: label, handler: handler})
+}
+
+// Values returns the values of the fields by key: a string for inputs, a
+// bool for checkboxes and the selected option, or an empty string, for
+// radio groups and dropdowns.
+func (f *Form) Values() map[string]interface{} {
+	values := make(map[string]interface{}, len(f.fields))
+	for _, fd := range f.fields {
+		switch fd.kind {
+		case formInput:
+			values[fd.key] = fd.input.Text()
+		case formCheckbox:
+			values[fd.key] = fd.checked
+		default:
+			values[fd.key] = fd.option()
+		}
+	}
+	return values
+}
+
+// Err returns the error shown in the form, which is set when it fails to
+// validate.
+func (f *Form) Err() error {
+	return f.err
+}
+
+// Submit validates the form and, if it is valid, calls its OnSubmit
+// handler. Otherwise, the error is shown and the invalid input, if any,
+// gets the focus.
+func (f *Form) Submit(g *Gui) error {
+	f.err = nil
+	for _, fd := range f.fields {
+		if fd.kind != formInput {
+			continue
+		}
+		fd.input.touched = true
+		fd.input.validate()
+		if err := fd.input.Err(); err != nil {
+			f.err = fmt.Errorf("%s: %v", strings.TrimSuffix(fd.label, ":"), err)
+			_, err := g.SetCurrentView(fd.input.Name())
+			return err
+		}
+	}
+
+	values := f.Values()
+	if f.Validate != nil {
+		if err := f.Validate(values); err != nil {
+			f.err = err
+			return nil
+		}
+	}
+	if f.OnSubmit != nil {
+		return f.OnSubmit(g, f, values)
+	}
+	return nil
+}
+
+// Cancel calls the OnCancel handler of the form.
+func (f *Form) Cancel(g *Gui) error {
+	if f.OnCancel != nil {
+		return f.OnCancel(g, f)
+	}
+	return nil
+}
+
+// Focus gives the focus to the first field of the form.
+func (f *Form) Focus(g *Gui) error {
+	return f.focus(g, 0)
+}
+
+// Layout draws the form, creating its views if needed. If no view has the
+// focus, or the view of the form itself has it, the first field gets it.
+func (f *Form) Layout(g *Gui) error {
+	v, err := g.SetView(f.name, f.x0, f.y0, f.x1, f.y1)
+	if err != nil {
+		if err != ErrUnknownView {
+			return err
+		}
+		v.Focusable = false
+		v.Padding = Padding{Left: 1, Right: 1}
+	}
+	v.Title = f.Title
+	v.Group = f.name
+
+	labelWidth := 0
+	for _, fd := range f.fields {
+		if w := TextWidth(fd.label); w > labelWidth {
+			labelWidth = w
+		}
+	}
+	if labelWidth > 0 {
+		labelWidth++
+	}
+	x0, x1 := f.x0+2+labelWidth, f.x1-2
+	if x1 < x0 {
+		x1 = x0
+	}
+
+	// the labels and the error are the content of the view of the form
+	var lines []string
+	y := f.y0 + 1
+	for _, fd := range f.fields {
+		lines = append(lines, fd.label)
+		rows, err := f.layoutField(g, v, fd, x0, y, x1)
+		if err != nil {
+			return err
+		}
+		for i := 1; i < rows; i++ {
+			lines = append(lines, "")
+		}
+		y += rows
+	}
+	lines = append(lines, "")
+	if f.err != nil {
+		lines = append(lines, "\x1b[31m"+f.err.Error()+"\x1b[0m")
+	}
+	v.Clear()
+	fmt.Fprint(v, strings.Join(lines, "\n"))
+
+	if err := f.layoutButtons(g, v, f.y1-1); err != nil {
+		return err
+	}
+	if err := f.layoutPopup(g, v); err != nil {
+		return err
+	}
+
+	if cur := g.CurrentView(); cur == nil || cur == v {
+		return f.Focus(g)
+	}
+	return nil
+}
+
+// layoutField places the view of the field, which takes the columns from x0
+// to x1 starting at row y, and returns the number of rows it takes.
+func (f *Form) layoutField(g *Gui, fv *View, fd *formField, x0, y, x1 int) (rows int, err error) {
+	name := f.name + "." + fd.key
+	focused := g.CurrentView() != nil && g.CurrentView().Name() == name
+
+	if fd.kind == formInput {
+		fd.input.SetPosition(x0-1, y-1, x1+1, y+1)
+		if err := fd.input.Layout(g); err != nil {
+			return 0, err
+		}
+		v, err := g.View(name)
+		if err != nil {
+			return 0, err
+		}
+		if v.Frame {
+			v.Frame = false
+			// Enter and Esc submit and cancel the form instead of the input
+			for _, k := range []Key{KeyEnter, KeyEsc} {
+				if err := v.DeleteKeybinding(k, ModNone); err != nil {
+					return 0, err
+				}
+			}
+			inputMouse := v.OnMouse
+			v.OnMouse = func(g *Gui, v *View, ev *MouseEvent) error {
+				if err := f.onMouse(g, v, ev); err != nil {
+					return err
+				}
+				return inputMouse(g, v, ev)
+			}
+			if err := f.bindField(v, fd); err != nil {
+				return 0, err
+			}
+		}
+		f.setFieldView(fv, v)
+		v.FgColor = fv.FgColor | AttrUnderline
+		return 1, nil
+	}
+
+	rows = 1
+	if fd.kind == formRadio && len(fd.options) > 0 {
+		rows = len(fd.options)
+	}
+	v, err := g.SetView(name, x0-1, y-1, x1+1, y+rows)
+	if err != nil {
+		if err != ErrUnknownView {
+			return 0, err
+		}
+		v.Frame = false
+		v.OnMouse = f.onMouse
+		if err := f.bindField(v, fd); err != nil {
+			return 0, err
+		}
+	}
+	f.setFieldView(fv, v)
+
+	v.Clear()
+	v.Highlight = false
+	v.FgColor = fv.FgColor
+	switch fd.kind {
+	case formCheckbox:
+		if fd.checked {
+			fmt.Fprint(v, "[x]")
+		} else {
+			fmt.Fprint(v, "[ ]")
+		}
+		if focused {
+			v.FgColor |= AttrReverse
+		}
+	case formRadio:
+		on, off := "(•) ", "( ) "
+		if g.ASCII {
+			on = "(*) "
+		}
+		for i, o := range fd.options {
+			if i > 0 {
+				fmt.Fprint(v, "\n")
+			}
+			if i == fd.selected {
+				fmt.Fprint(v, on+o)
+			} else {
+				fmt.Fprint(v, off+o)
+			}
+		}
+		if focused && fd.selected >= 0 {
+			v.Highlight = true
+			v.SelFgColor, v.SelBgColor = fv.FgColor|AttrReverse, fv.BgColor
+			v.SetCursor(0, fd.selected)
+		}
+	case formDropdown:
+		arrow := " ▾"
+		if g.ASCII {
+			arrow = " v"
+		}
+		w := x1 - x0 + 1 - TextWidth(arrow)
+		if w < 0 {
+			w = 0
+		}
+		text := []rune(fd.option())
+		if len(text) > w {
+			text = text[:w]
+		}
+		pad := ""
+		if n := w - len(text); n > 0 {
+			pad = strings.Repeat(" ", n)
+		}
+		fmt.Fprint(v, string(text)+pad+arrow)
+		if focused {
+			v.FgColor |= AttrReverse
+		}
+	}
+	return rows, nil
+}
+
+// layoutButtons places the buttons of the form, centered at row y.
+func (f *Form) layoutButtons(g *Gui, fv *View, y int) error {
+	bw := 2 * (len(f.buttons) - 1)
+	for _, b := range f.buttons {
+		bw += TextWidth(b.label) + 4
+	}
+	x := f.x0 + (f.x1-f.x0+1-bw)/2
+	for i, b := range f.buttons {
+		name := fmt.Sprintf("%s.button%d", f.name, i)
+		n := TextWidth(b.label) + 4
+		v, err := g.SetView(name, x-1, y-1, x+n, y+1)
+		if err != nil {
+			if err != ErrUnknownView {
+				return err
+			}
+			v.Frame = false
+			v.OnMouse = f.onMouse
+			fmt.Fprintf(v, "[ %s ]", b.label)
+			if err := f.bindButton(v, b); err != nil {
+				return err
+			}
+		}
+		f.setFieldView(fv, v)
+		v.FgColor = fv.FgColor
+		if g.CurrentView() == v {
+			v.FgColor |= AttrReverse
+		}
+		x += n + 2
+	}
+	return nil
+}
+
+// layoutPopup places the list of the open dropdown under it. The dropdown
+// is closed when the list loses the focus.
+func (f *Form) layoutPopup(g *Gui, fv *View) error {
+	if f.popup == nil {
+		return nil
+	}
+	dv, err := g.View(f.name + "." + f.popupField.key)
+	if err != nil {
+		return f.closePopup(g)
+	}
+	if pv, err := g.View(f.popup.Name()); err == nil && g.CurrentView() != pv {
+		return f.closePopup(g)
+	}
+
+	// the view of the dropdown has no frame, so its content is at y0+1
+	h := len(f.popupField.options) + 1
+	y0 := dv.y1
+	if _, maxY := g.Size(); y0+h >= maxY && dv.y0-h >= 0 {
+		// show the list over the dropdown if it does not fit under it
+		y0 = dv.y0 - h
+	}
+	f.popup.SetPosition(dv.x0, y0, dv.x1, y0+h)
+	if err := f.popup.Layout(g); err != nil {
+		return err
+	}
+
+	pv, err := g.View(f.popup.Name())
+	if err != nil {
+		return err
+	}
+	f.setFieldView(fv, pv)
+	pv.ZIndex++
+	if g.CurrentView() != pv {
+		if err := pv.SetKeybinding(KeyEsc, ModNone, func(g *Gui, v *View) error {
+			return f.closePopup(g)
+		}); err != nil {
+			return err
+		}
+		if _, err := g.SetCurrentView(pv.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFieldView configures a view that belongs to the form, which is shown
+// on top of the view of the form.
+func (f *Form) setFieldView(fv, v *View) {
+	v.Group = f.name
+	v.Layer = fv.Layer
+	v.ZIndex = fv.ZIndex
+	v.Visible = fv.Visible
+}
+
+// bindField sets the keybindings of the view of a field.
+func (f *Form) bindField(v *View, fd *formField) error {
+	if err := f.bindCommon(v); err != nil {
+		return err
+	}
+
+	type binding struct {
+		key     Key
+		handler func(*Gui, *View) error
+	}
+	var bindings []binding
+	switch fd.kind {
+	case formInput, formCheckbox, formRadio:
+		bindings = append(bindings, binding{KeyEnter, f.submitHandler})
+	}
+	switch fd.kind {
+	case formCheckbox:
+		bindings = append(bindings, binding{KeySpace, func(g *Gui, v *View) error {
+			fd.checked = !fd.checked
+			return nil
+		}})
+	case formRadio, formDropdown:
+		for _, k := range []Key{KeyArrowUp, KeyArrowLeft} {
+			bindings = append(bindings, binding{k, fd.moveHandler(-1)})
+		}
+		for _, k := range []Key{KeyArrowDown, KeyArrowRight} {
+			bindings = append(bindings, binding{k, fd.moveHandler(1)})
+		}
+	}
+	if fd.kind == formDropdown {
+		open := func(g *Gui, v *View) error {
+			return f.openPopup(fd)
+		}
+		bindings = append(bindings, binding{KeyEnter, open}, binding{KeySpace, open})
+	}
+
+	for _, b := range bindings {
+		if err := v.SetKeybinding(b.key, ModNone, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindButton sets the keybindings of the view of a button.
+func (f *Form) bindButton(v *View, b *formButton) error {
+	if err := f.bindCommon(v); err != nil {
+		return err
+	}
+	activate := func(g *Gui, v *View) error {
+		return b.handler(g)
+	}
+	if err := v.SetKeybinding(KeyEnter, ModNone, activate); err != nil {
+		return err
+	}
+	return v.SetKeybinding(KeySpace, ModNone, activate)
+}
+
+// bindCommon sets the keybindings shared by all the views of the form.
+func (f *Form) bindCommon(v *View) error {
+	if err := v.SetKeybinding(KeyTab, ModNone, f.focusHandler(1)); err != nil {
+		return err
+	}
+	if err := v.SetKeybinding(KeyTab, ModShift, f.focusHandler(-1)); err != nil {
+		return err
+	}
+	return v.SetKeybinding(KeyEsc, ModNone, func(g *Gui, v *View) error {
+		return f.Cancel(g)
+	})
+}
+
+// submitHandler submits the form.
+func (f *Form) submitHandler(g *Gui, v *View) error {
+	return f.Submit(g)
+}
+
+// focusNames returns the names of the views of the fields and the buttons,
+// in focus order.
+func (f *Form) focusNames() []string {
+	var names []string
+	for _, fd := range f.fields {
+		names = append(names, f.name+"."+fd.key)
+	}
+	for i := range f.buttons {
+		names = append(names, fmt.Sprintf("%s.button%d", f.name, i))
+	}
+	return names
+}
+
+// focus gives the focus to the field or button at the given position.
+func (f *Form) focus(g *Gui, i int) error {
+	names := f.focusNames()
+	if len(names) == 0 {
+		return nil
+	}
+	i = (i%len(names) + len(names)) % len(names)
+	_, err := g.SetCurrentView(names[i])
+	if err == ErrUnknownView {
+		// the form has not been laid out yet
+		return nil
+	}
+	return err
+}
+
+// focusHandler returns a handler that moves the focus by the given number
+// of fields.
+func (f *Form) focusHandler(delta int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		for i, name := range f.focusNames() {
+			if name == v.Name() {
+				return f.focus(g, i+delta)
+			}
+		}
+		return f.focus(g, 0)
+	}
+}
+
+// openPopup opens the list of a dropdown.
+func (f *Form) openPopup(fd *formField) error {
+	if len(fd.options) == 0 {
+		return nil
+	}
+	l := NewList(f.name+"."+fd.key+".list", 0, 0, 0, 0, fd.options)
+	l.SetCursor(fd.selected)
+	l.OnSelect = func(g *Gui, l *List, index int) error {
+		fd.selected = index
+		return f.closePopup(g)
+	}
+	f.popup, f.popupField = l, fd
+	return nil
+}
+
+// closePopup closes the list of the open dropdown.
+func (f *Form) closePopup(g *Gui) error {
+	name := f.popup.Name()
+	f.popup, f.popupField = nil, nil
+	if err := g.DeleteView(name); err != nil && err != ErrUnknownView {
+		return err
+	}
+	return nil
+}
+
+// onMouse gives the focus to the clicked field or button and handles the
+// clicks on checkboxes, radio groups, dropdowns and buttons.
+func (f *Form) onMouse(g *Gui, v *View, ev *MouseEvent) error {
+	if ev.Action != MouseActionPress || ev.Button != MouseLeft {
+		return nil
+	}
+	if _, err := g.SetCurrentView(v.Name()); err != nil {
+		return err
+	}
+
+	for i, b := range f.buttons {
+		if v.Name() == fmt.Sprintf("%s.button%d", f.name, i) {
+			return b.handler(g)
+		}
+	}
+	for _, fd := range f.fields {
+		if v.Name() != f.name+"."+fd.key {
+			continue
+		}
+		switch fd.kind {
+		case formCheckbox:
+			fd.checked = !fd.checked
+		case formRadio:
+			if ev.ViewY >= 0 && ev.ViewY < len(fd.options) {
+				fd.selected = ev.ViewY
+			}
+		case formDropdown:
+			return f.openPopup(fd)
+		}
+	}
+	return nil
+}
+
+// option returns the selected option of a radio group or dropdown, or an
+// empty string if none is selected.
+func (fd *formField) option() string {
+	if fd.selected < 0 || fd.selected >= len(fd.options) {
+		return ""
+	}
+	return fd.options[fd.selected]
+}
+
+// moveHandler returns a handler that selects the option delta positions
+// away from the selected one.
+func (fd *formField) moveHandler(delta int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		if len(fd.options) == 0 {
+			return nil
+		}
+		i := fd.selected + delta
+		if i < 0 {
+			i = 0
+		}
+		if i >= len(fd.options) {
+			i = len(fd.options) - 1
+		}
+		fd.selected = i
+		return nil
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+// FrameStyle defines the runes used to draw the frame of a view. Runes that
+// are not set (zero) are taken from FrameSingle.
+type FrameStyle struct {
+	// Horizontal and Vertical are the runes of the edges.
+	Horizontal, Vertical rune
+
+	// TopLeft, TopRight, BottomLeft and BottomRight are the runes of the
+	// corners.
+	TopLeft, TopRight, BottomLeft, BottomRight rune
+
+	// JunctionLeft, JunctionRight, JunctionTop, JunctionBottom and
+	// JunctionCross are the runes used where the frame meets the frames of
+	// other views: ├, ┤, ┬, ┴ and ┼ respectively.
+	JunctionLeft, JunctionRight, JunctionTop, JunctionBottom, JunctionCross rune
+
+	// HideTop, HideBottom, HideLeft and HideRight allow to omit the
+	// corresponding sides of the frame. The content of the view is not
+	// moved.
+	HideTop, HideBottom, HideLeft, HideRight bool
+}
+
+// Predefined frame styles.
+var (
+	FrameSingle = FrameStyle{
+		Horizontal: '─', Vertical: '│',
+		TopLeft: '┌', TopRight: '┐', BottomLeft: '└', BottomRight: '┘',
+		JunctionLeft: '├', JunctionRight: '┤', JunctionTop: '┬', JunctionBottom: '┴', JunctionCross: '┼',
+	}
+
+	FrameRounded = FrameStyle{
+		Horizontal: '─', Vertical: '│',
+		TopLeft: '╭', TopRight: '╮', BottomLeft: '╰', BottomRight: '╯',
+		JunctionLeft: '├', JunctionRight: '┤', JunctionTop: '┬', JunctionBottom: '┴', JunctionCross: '┼',
+	}
+
+	FrameDouble = FrameStyle{
+		Horizontal: '═', Vertical: '║',
+		TopLeft: '╔', TopRight: '╗', BottomLeft: '╚', BottomRight: '╝',
+		JunctionLeft: '╠', JunctionRight: '╣', JunctionTop: '╦', JunctionBottom: '╩', JunctionCross: '╬',
+	}
+
+	FrameHeavy = FrameStyle{
+		Horizontal: '━', Vertical: '┃',
+		TopLeft: '┏', TopRight: '┓', BottomLeft: '┗', BottomRight: '┛',
+		JunctionLeft: '┣', JunctionRight: '┫', JunctionTop: '┳', JunctionBottom: '┻', JunctionCross: '╋',
+	}
+
+	FrameDashed = FrameStyle{
+		Horizontal: '┄', Vertical: '┆',
+		TopLeft: '┌', TopRight: '┐', BottomLeft: '└', BottomRight: '┘',
+		JunctionLeft: '├', JunctionRight: '┤', JunctionTop: '┬', JunctionBottom: '┴', JunctionCross: '┼',
+	}
+
+	// FrameASCII is used for all the frames when Gui.ASCII is true.
+	FrameASCII = FrameStyle{
+		Horizontal: '-', Vertical: '|',
+		TopLeft: '+', TopRight: '+', BottomLeft: '+', BottomRight: '+',
+		JunctionLeft: '+', JunctionRight: '+', JunctionTop: '+', JunctionBottom: '+', JunctionCross: '+',
+	}
+)
+
+// Directions of the lines that meet in a frame cell. They are combined to
+// merge the frames of adjacent views into the proper junctions.
+const (
+	lineUp uint8 = 1 << iota
+	lineDown
+	lineLeft
+	lineRight
+
+	// lineCurrent marks the cells drawn by the frame of the current view.
+	lineCurrent
+
+	lineH   = lineLeft | lineRight
+	lineV   = lineUp | lineDown
+	lineAll = lineH | lineV
+)
+
+// rune returns the rune that joins the given lines.
+func (s FrameStyle) rune(lines uint8) rune {
+	var ch rune
+	switch lines & lineAll {
+	case lineUp, lineDown, lineV:
+		ch = s.Vertical
+	case lineLeft, lineRight, lineH:
+		ch = s.Horizontal
+	case lineDown | lineRight:
+		ch = s.TopLeft
+	case lineDown | lineLeft:
+		ch = s.TopRight
+	case lineUp | lineRight:
+		ch = s.BottomLeft
+	case lineUp | lineLeft:
+		ch = s.BottomRight
+	case lineV | lineRight:
+		ch = s.JunctionLeft
+	case lineV | lineLeft:
+		ch = s.JunctionRight
+	case lineH | lineDown:
+		ch = s.JunctionTop
+	case lineH | lineUp:
+		ch = s.JunctionBottom
+	case lineAll:
+		ch = s.JunctionCross
+	}
+	if ch == 0 && s != FrameSingle {
+		return FrameSingle.rune(lines)
+	}
+	return ch
+}
+
+// frameStyle returns the style used to draw the frame of v. The runes are
+// taken from the style that applies to the view, but the sides hidden by
+// the style of the view stay hidden.
+func (g *Gui) frameStyle(v *View) FrameStyle {
+	runes := v.FrameStyle
+	runes.HideTop, runes.HideBottom, runes.HideLeft, runes.HideRight = false, false, false, false
+
+	var style FrameStyle
+	switch {
+	case g.ASCII:
+		style = FrameASCII
+	case v == g.currentView && g.SelFrameStyle != (FrameStyle{}):
+		style = g.SelFrameStyle
+	case runes != (FrameStyle{}):
+		style = runes
+	default:
+		style = g.FrameStyle
+	}
+	style.HideTop = style.HideTop || v.FrameStyle.HideTop
+	style.HideBottom = style.HideBottom || v.FrameStyle.HideBottom
+	style.HideLeft = style.HideLeft || v.FrameStyle.HideLeft
+	style.HideRight = style.HideRight || v.FrameStyle.HideRight
+	return style
+}
+
+// frameColors returns the colors used to draw the frame of v.
+func (g *Gui) frameColors(v *View) (fgColor, bgColor Attribute) {
+	if g.Highlight && v == g.currentView {
+		return g.SelFgColor, g.SelBgColor
+	}
+	return v.FrameFgColor, v.FrameBgColor
+}
+
+// frameCell holds the frame lines drawn in a cell and the stacking level of
+// the view that drew them.
+type frameCell struct {
+	lines  uint8
+	layer  Layer
+	zIndex int
+}
+
+// setFrameRune draws the frame cell of v at the given position. If the cell
+// already belongs to the frame of another view with the same layer and
+// z-index, both frames are merged into the corresponding junction, which
+// keeps the style and colors of the current view if it is one of them.
+// Straight edges crossing each other and frames of views stacked at other
+// levels, like popups, are not merged, so overlapping views are drawn on
+// top of each other.
+func (g *Gui) setFrameRune(v *View, x, y int, lines uint8) error {
+	owner := v
+	i := y*g.maxX + x
+	if prev := g.frameLines[i]; prev.lines != 0 && prev.layer == v.Layer && prev.zIndex == v.ZIndex {
+		straight := func(l uint8) bool { return l&lineAll == lineH || l&lineAll == lineV }
+		if !straight(prev.lines) || !straight(lines) {
+			lines |= prev.lines & lineAll
+		}
+		if prev.lines&lineCurrent != 0 && g.currentView != nil {
+			owner = g.currentView
+		}
+	}
+	if owner == g.currentView {
+		lines |= lineCurrent
+	}
+	g.frameLines[i] = frameCell{lines: lines, layer: v.Layer, zIndex: v.ZIndex}
+
+	fgColor, bgColor := g.frameColors(owner)
+	return g.SetRune(x, y, g.frameStyle(owner).rune(lines), fgColor, bgColor)
+}
+
+// resetFrameLines forgets the frame lines drawn in the last flush.
+func (g *Gui) resetFrameLines() {
+	n := g.maxX * g.maxY
+	if cap(g.frameLines) < n {
+		g.frameLines = make([]frameCell, n)
+		return
+	}
+	g.frameLines = g.frameLines[:n]
+	for i := range g.frameLines {
+		g.frameLines[i] = frameCell{}
+	}
+}
+
+// clearFrameLines forgets the frame lines inside the given rectangle,
+// because they have been covered by something else.
+func (g *Gui) clearFrameLines(x0, y0, x1, y1 int) {
+	if x0 < 0 {
+		x0 = 0
+	}
+	if y0 < 0 {
+		y0 = 0
+	}
+	if x1 >= g.maxX {
+		x1 = g.maxX - 1
+	}
+	if y1 >= g.maxY {
+		y1 = g.maxY - 1
+	}
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			g.frameLines[y*g.maxX+x] = frameCell{}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import "testing"
+
+func TestFrameStyleKeepsHiddenSides(t *testing.T) {
+	g := &Gui{FrameStyle: FrameSingle, SelFrameStyle: FrameHeavy}
+	v := newView("a", 0, 0, 10, 5, OutputNormal)
+	v.FrameStyle = FrameStyle{HideLeft: true, HideRight: true}
+	other := newView("b", 0, 6, 10, 10, OutputNormal)
+
+	tests := []struct {
+		name    string
+		current *View
+		ascii   bool
+		runes   FrameStyle
+	}{
+		{"not current", other, false, FrameSingle},
+		{"current", v, false, FrameHeavy},
+		{"ascii", v, true, FrameASCII},
+	}
+	for _, tt := range tests {
+		g.currentView, g.ASCII = tt.current, tt.ascii
+		s := g.frameStyle(v)
+		if !s.HideLeft || !s.HideRight || s.HideTop || s.HideBottom {
+			t.Errorf("%s: got hidden sides %v %v %v %v, want left and right",
+				tt.name, s.HideTop, s.HideBottom, s.HideLeft, s.HideRight)
+		}
+		if s.Horizontal != tt.runes.Horizontal || s.TopLeft != tt.runes.TopLeft {
+			t.Errorf("%s: got runes %q %q, want %q %q",
+				tt.name, s.Horizontal, s.TopLeft, tt.runes.Horizontal, tt.runes.TopLeft)
+		}
+	}
+}
+
+func TestSetFrameRuneMergesSameLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		layer    Layer
+		zIndex   int
+		want     uint8
+		wantRune rune
+	}{
+		{"same level", LayerNormal, 0, lineH | lineDown | lineRight, '┬'},
+		{"other layer", LayerPopup, 0, lineDown | lineRight, '┌'},
+		{"other z-index", LayerNormal, 1, lineDown | lineRight, '┌'},
+	}
+	for _, tt := range tests {
+		g := &Gui{maxX: 10, maxY: 10, FrameStyle: FrameSingle}
+		g.resetFrameLines()
+		lower := newView("lower", 0, 0, 9, 9, OutputNormal)
+		upper := newView("upper", 5, 5, 9, 9, OutputNormal)
+		upper.Layer, upper.ZIndex = tt.layer, tt.zIndex
+
+		if err := g.setFrameRune(lower, 5, 5, lineH); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.setFrameRune(upper, 5, 5, lineDown|lineRight); err != nil {
+			t.Fatal(err)
+		}
+		got := g.frameLines[5*g.maxX+5].lines & lineAll
+		if got != tt.want {
+			t.Errorf("%s: got lines %b, want %b", tt.name, got, tt.want)
+		}
+		if r := FrameSingle.rune(got); r != tt.wantRune {
+			t.Errorf("%s: got rune %q, want %q", tt.name, r, tt.wantRune)
+		}
+	}
+}
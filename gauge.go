@@ -0,0 +1,179 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"fmt"
+	"math"
+)
+
+// GaugeThreshold sets the color of a Gauge when its value reaches Value.
+type GaugeThreshold struct {
+	Value float64
+	Color Attribute
+}
+
+// Gauge is a widget that shows a value in a range, like the usage of a
+// resource, as a horizontal or vertical bar drawn with eighths of a cell.
+// The color of the bar can change when the value reaches some thresholds.
+type Gauge struct {
+	widget
+
+	// Title is the title of the view of the gauge.
+	Title string
+
+	// Label is the text shown before a horizontal bar or above a vertical
+	// one.
+	Label string
+
+	// Min and Max are the limits of the range of the value.
+	Min, Max float64
+
+	// If Vertical is true, the bar grows from the bottom to the top.
+	Vertical bool
+
+	// Thresholds are the values where the color of the bar changes, in
+	// ascending order. The color of the highest threshold reached by the
+	// value is used.
+	Thresholds []GaugeThreshold
+
+	// Format, if not nil, returns the text shown after a horizontal bar or
+	// below a vertical one. Otherwise, the percentage of the range is
+	// shown.
+	Format func(value float64) string
+
+	// FgColor and BgColor are the colors of the filled and the empty parts
+	// of the bar when no threshold is reached. If FgColor is ColorDefault,
+	// the color of the view is used.
+	FgColor, BgColor Attribute
+
+	value float64
+}
+
+// NewGauge returns a new Gauge with the given name, position and range.
+func NewGauge(name string, x0, y0, x1, y1 int, min, max float64) *Gauge {
+	return &Gauge{
+		widget: widget{name: name, x0: x0, y0: y0, x1: x1, y1: y1},
+		Min:    min,
+		Max:    max,
+		value:  min,
+	}
+}
+
+// SetValue sets the value of the gauge.
+func (ga *Gauge) SetValue(value float64) {
+	ga.value = value
+}
+
+// Value returns the value of the gauge.
+func (ga *Gauge) Value() float64 {
+	return ga.value
+}
+
+// fraction returns the position of the value in the range, from 0 to 1.
+func (ga *Gauge) fraction() float64 {
+	if ga.Max <= ga.Min {
+		return 0
+	}
+	f := (ga.value - ga.Min) / (ga.Max - ga.Min)
+	return math.Max(0, math.Min(1, f))
+}
+
+// color returns the color of the bar for the current value.
+func (ga *Gauge) color(v *View) Attribute {
+	color := ga.FgColor
+	if color == ColorDefault {
+		color = v.FgColor
+	}
+	for _, t := range ga.Thresholds {
+		if ga.value >= t.Value {
+			color = t.Color
+		}
+	}
+	return color
+}
+
+// Layout draws the gauge.
+func (ga *Gauge) Layout(g *Gui) error {
+	v, err := g.SetView(ga.name, ga.x0, ga.y0, ga.x1, ga.y1)
+	if err != nil {
+		if err != ErrUnknownView {
+			return err
+		}
+		v.Focusable = false
+	}
+	v.Title = ga.Title
+	v.Highlight = false
+
+	var text string
+	if ga.Format != nil {
+		text = ga.Format(ga.value)
+	} else {
+		text = fmt.Sprintf("%d%%", int(math.Round(ga.fraction()*100)))
+	}
+
+	fgColor := ga.color(v)
+	if !ga.Vertical {
+		drawBar(g, v, ga.Label, text, func(width int) []cell {
+			return barCells(g, ga.fraction(), width, fgColor, ga.BgColor)
+		})
+		return nil
+	}
+	ga.drawVertical(g, v, text, fgColor)
+	return nil
+}
+
+// drawVertical draws a vertical bar, with the label on the top row and the
+// text on the bottom one if there is room for them.
+func (ga *Gauge) drawVertical(g *Gui, v *View, text string, fgColor Attribute) {
+	w, h := v.Size()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	label := ga.Label
+	if h < 3 {
+		label, text = "", ""
+	}
+	top, bottom := 0, h
+	if label != "" {
+		top++
+	}
+	if text != "" {
+		bottom--
+	}
+
+	lines := make([][]cell, 0, h)
+	if label != "" {
+		lines = append(lines, alignCells(g, label, w, AlignCenter, v.FgColor, v.BgColor))
+	}
+	bar := barCells(g, ga.fraction(), bottom-top, fgColor, ga.BgColor)
+	for y := top; y < bottom; y++ {
+		// the bar grows upwards, so its first cell is the bottom row
+		c := bar[bottom-1-y]
+		if !g.ASCII {
+			c.chr = lowerBlocks[indexRune(leftBlocks, c.chr)]
+		}
+		line := make([]cell, w)
+		for x := range line {
+			line[x] = c
+		}
+		lines = append(lines, line)
+	}
+	if text != "" {
+		lines = append(lines, alignCells(g, text, w, AlignCenter, v.FgColor, v.BgColor))
+	}
+	v.SetOrigin(0, 0)
+	setLines(v, lines)
+}
+
+// indexRune returns the index of r in runes, or -1 if it is not found.
+func indexRune(runes []rune, r rune) int {
+	for i, rr := range runes {
+		if rr == r {
+			return i
+		}
+	}
+	return -1
+}
@@ -33,7 +33,7 @@ const (
 // Gui represents the whole User Interface, including the views, layouts
 // and keybindings.
 type Gui struct {
-	tbEvents      chan termbox.Event
+	events        chan event
 	userEvents    chan userEvent
 	views         []*View
 	currentView   *View
@@ -41,7 +41,16 @@ type Gui struct {
 	resizeHandler func(g *Gui, x, y int) error
 	keybindings   []*keybinding
 	maxX, maxY    int
+	frameLines    []frameCell
 	outputMode    OutputMode
+	mode          Mode
+	editModes     map[Mode]bool
+	modeHandler   func(g *Gui, from, to Mode) error
+	mouse         mouseState
+	dialogs       []*dialog
+	dialogSeq     int
+	focusHistory  []*View
+	viewSeq       int
 
 	// BgColor and FgColor allow to configure the background and foreground
 	// colors of the GUI.
@@ -55,16 +64,41 @@ type Gui struct {
 	// frame of the current view.
 	Highlight bool
 
+	// FrameStyle is the style of the frames of the views that do not set
+	// their own. It defaults to FrameSingle.
+	FrameStyle FrameStyle
+
+	// If SelFrameStyle is set, it is used to draw the frame of the current
+	// view, so it can be told apart without relying on colors.
+	SelFrameStyle FrameStyle
+
 	// If Cursor is true then the cursor is enabled.
 	Cursor bool
 
 	// If Mouse is true then mouse events will be enabled.
 	Mouse bool
 
+	// If MouseMotion is true, mouse motion is reported even when no button
+	// is pressed, which allows to track the view under the mouse. It
+	// requires Mouse and must be set before calling MainLoop.
+	MouseMotion bool
+
 	// If InputEsc is true, when ESC sequence is in the buffer and it doesn't
 	// match any known sequence, ESC means KeyEsc.
 	InputEsc bool
 
+	// If BracketedPaste is true, the terminal is asked to delimit pasted
+	// text, which is then delivered to the Editor of the current view as a
+	// whole instead of as individual key-presses. See PasteEditor. It must
+	// be set before calling MainLoop.
+	BracketedPaste bool
+
+	// If ExtendedKeys is true, the terminal is asked to report modified keys
+	// using the kitty keyboard protocol or xterm's modifyOtherKeys, so
+	// combinations like Ctrl+Enter, Shift+Tab or Ctrl+ArrowLeft can be told
+	// apart. It must be set before calling MainLoop.
+	ExtendedKeys bool
+
 	// If ASCII is true then use ASCII instead of unicode to draw the
 	// interface. Using ASCII is more portable.
 	ASCII bool
@@ -81,13 +115,20 @@ func NewGui(mode OutputMode) (*Gui, error) {
 	g.outputMode = mode
 	termbox.SetOutputMode(termbox.OutputMode(mode))
 
-	g.tbEvents = make(chan termbox.Event, 20)
+	g.events = make(chan event, 20)
 	g.userEvents = make(chan userEvent, 20)
 
 	g.maxX, g.maxY = termbox.Size()
 
 	g.BgColor, g.FgColor = ColorDefault, ColorDefault
 	g.SelBgColor, g.SelFgColor = ColorDefault, ColorDefault
+	g.FrameStyle = FrameSingle
+
+	g.editModes = map[Mode]bool{
+		ModeNone:    true,
+		ModeInsert:  true,
+		ModeCommand: true,
+	}
 
 	return g, nil
 }
@@ -95,6 +136,7 @@ func NewGui(mode OutputMode) (*Gui, error) {
 // Close finalizes the library. It should be called after a successful
 // initialization and when gotui is not needed anymore.
 func (g *Gui) Close() {
+	g.resetInputModes()
 	termbox.Close()
 }
 
@@ -149,11 +191,14 @@ func (g *Gui) SetView(name string, x0, y0, x1, y1 int) (*View, error) {
 	v := newView(name, x0, y0, x1, y1, g.outputMode)
 	v.BgColor, v.FgColor = g.BgColor, g.FgColor
 	v.SelBgColor, v.SelFgColor = g.SelBgColor, g.SelFgColor
+	g.viewSeq++
+	v.seq = g.viewSeq
 	g.views = append(g.views, v)
 	return v, ErrUnknownView
 }
 
-// SetViewOnTop sets the given view on top of the existing ones.
+// SetViewOnTop sets the given view on top of the existing ones in the same
+// layer and with the same z-index.
 func (g *Gui) SetViewOnTop(name string) (*View, error) {
 	for i, v := range g.views {
 		if v.name == name {
@@ -165,7 +210,8 @@ func (g *Gui) SetViewOnTop(name string) (*View, error) {
 	return nil, ErrUnknownView
 }
 
-// SetViewOnBottom sets the given view on bottom of the existing ones.
+// SetViewOnBottom sets the given view on bottom of the existing ones in the
+// same layer and with the same z-index.
 func (g *Gui) SetViewOnBottom(name string) (*View, error) {
 	for i, v := range g.views {
 		if v.name == name {
@@ -177,7 +223,8 @@ func (g *Gui) SetViewOnBottom(name string) (*View, error) {
 	return nil, ErrUnknownView
 }
 
-// Views returns all the views in the GUI.
+// Views returns all the views in the GUI, in stacking order from bottom to
+// top.
 func (g *Gui) Views() []*View {
 	return g.views
 }
@@ -199,7 +246,7 @@ func (g *Gui) ViewByPosition(x, y int) (*View, error) {
 	// traverse views in reverse order checking top views first
 	for i := len(g.views); i > 0; i-- {
 		v := g.views[i-1]
-		if x > v.x0 && x < v.x1 && y > v.y0 && y < v.y1 {
+		if v.Visible && x > v.x0 && x < v.x1 && y > v.y0 && y < v.y1 {
 			return v, nil
 		}
 	}
@@ -217,23 +264,38 @@ func (g *Gui) ViewPosition(name string) (x0, y0, x1, y1 int, err error) {
 	return 0, 0, 0, 0, ErrUnknownView
 }
 
-// DeleteView deletes a view by name.
+// DeleteView deletes a view by name. If it is the current view, the focus
+// is given back to the previous one. See RestoreFocus.
 func (g *Gui) DeleteView(name string) error {
 	for i, v := range g.views {
 		if v.name == name {
 			g.views = append(g.views[:i], g.views[i+1:]...)
+			if g.mouse.capture == v {
+				g.mouse.capture = nil
+			}
+			if g.mouse.hover == v {
+				g.mouse.hover = nil
+			}
+			if g.mouse.scroll.view == v {
+				g.mouse.scroll.view = nil
+			}
+			g.forgetFocus(v)
+			if g.currentView == v {
+				g.currentView = nil
+				return g.RestoreFocus()
+			}
 			return nil
 		}
 	}
 	return ErrUnknownView
 }
 
-// SetCurrentView gives the focus to a given view.
+// SetCurrentView gives the focus to a given view, calling the OnBlur handler
+// of the previous current view and the OnFocus handler of the new one.
 func (g *Gui) SetCurrentView(name string) (*View, error) {
 	for _, v := range g.views {
 		if v.name == name {
-			g.currentView = v
-			return v, nil
+			return v, g.setFocus(v, true)
 		}
 	}
 	return nil, ErrUnknownView
@@ -249,26 +311,38 @@ func (g *Gui) CurrentView() *View {
 // (empty string) then the keybinding will apply to all views. key must
 // be a rune or a Key.
 func (g *Gui) SetKeybinding(viewname string, key interface{}, mod Modifier, handler func(*Gui, *View) error) error {
+	return g.SetModeKeybinding(ModeNone, viewname, key, mod, handler)
+}
+
+// SetModeKeybinding creates a new keybinding that is only triggered while
+// the GUI is in the given mode. If mode equals to ModeNone, the keybinding
+// will apply to all modes.
+func (g *Gui) SetModeKeybinding(mode Mode, viewname string, key interface{}, mod Modifier, handler func(*Gui, *View) error) error {
 	var kb *keybinding
 
 	k, ch, err := getKey(key)
 	if err != nil {
 		return err
 	}
-	kb = newKeybinding(viewname, k, ch, mod, handler)
+	kb = newKeybinding(mode, viewname, k, ch, mod, handler)
 	g.keybindings = append(g.keybindings, kb)
 	return nil
 }
 
 // DeleteKeybinding deletes a keybinding.
 func (g *Gui) DeleteKeybinding(viewname string, key interface{}, mod Modifier) error {
+	return g.DeleteModeKeybinding(ModeNone, viewname, key, mod)
+}
+
+// DeleteModeKeybinding deletes a keybinding of the given mode.
+func (g *Gui) DeleteModeKeybinding(mode Mode, viewname string, key interface{}, mod Modifier) error {
 	k, ch, err := getKey(key)
 	if err != nil {
 		return err
 	}
 
 	for i, kb := range g.keybindings {
-		if kb.viewName == viewname && kb.ch == ch && kb.key == k && kb.mod == mod {
+		if kb.mode == mode && kb.viewName == viewname && kb.ch == ch && kb.key == k && kb.mod == mod {
 			g.keybindings = append(g.keybindings[:i], g.keybindings[i+1:]...)
 			return nil
 		}
@@ -276,7 +350,8 @@ func (g *Gui) DeleteKeybinding(viewname string, key interface{}, mod Modifier) e
 	return errors.New("keybinding not found")
 }
 
-// DeleteKeybindings deletes all keybindings of view.
+// DeleteKeybindings deletes all keybindings of view, regardless of their
+// mode.
 func (g *Gui) DeleteKeybindings(viewname string) {
 	var s []*keybinding
 	for _, kb := range g.keybindings {
@@ -287,6 +362,17 @@ func (g *Gui) DeleteKeybindings(viewname string) {
 	g.keybindings = s
 }
 
+// DeleteModeKeybindings deletes all keybindings of mode.
+func (g *Gui) DeleteModeKeybindings(mode Mode) {
+	var s []*keybinding
+	for _, kb := range g.keybindings {
+		if kb.mode != mode {
+			s = append(s, kb)
+		}
+	}
+	g.keybindings = s
+}
+
 // getKey takes an empty interface with a key and returns the corresponding
 // typed Key or rune.
 func getKey(key interface{}) (Key, rune, error) {
@@ -338,8 +424,12 @@ func (g *Gui) SetManager(managers ...Manager) {
 	g.currentView = nil
 	g.views = nil
 	g.keybindings = nil
+	g.mouse.capture, g.mouse.hover = nil, nil
+	g.mouse.scroll.view = nil
+	g.dialogs = nil
+	g.focusHistory = nil
 
-	go func() { g.tbEvents <- termbox.Event{Type: termbox.EventResize} }()
+	go func() { g.events <- event{typ: eventResize} }()
 }
 
 // SetManagerFunc sets the given manager function. It deletes all views and
@@ -367,11 +457,7 @@ func (g *Gui) MainLoopWithContext(ctx context.Context) error {
 		ctx = context.Background()
 	}
 
-	go func() {
-		for {
-			g.tbEvents <- termbox.PollEvent()
-		}
-	}()
+	go g.pollEvents()
 
 	inputMode := termbox.InputAlt
 	if g.InputEsc {
@@ -381,6 +467,9 @@ func (g *Gui) MainLoopWithContext(ctx context.Context) error {
 		inputMode |= termbox.InputMouse
 	}
 	termbox.SetInputMode(inputMode)
+	if err := g.setInputModes(); err != nil {
+		return err
+	}
 
 	if err := g.flush(); err != nil {
 		return err
@@ -389,7 +478,7 @@ func (g *Gui) MainLoopWithContext(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case ev := <-g.tbEvents:
+		case ev := <-g.events:
 			if err := g.handleEvent(&ev); err != nil {
 				return err
 			}
@@ -413,7 +502,7 @@ func (g *Gui) consumeevents(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case ev := <-g.tbEvents:
+		case ev := <-g.events:
 			if err := g.handleEvent(&ev); err != nil {
 				return err
 			}
@@ -432,14 +521,16 @@ func (g *Gui) consumeevents(ctx context.Context) error {
 
 // handleEvent handles an event, based on its type (key-press, error,
 // etc.)
-func (g *Gui) handleEvent(ev *termbox.Event) error {
-	switch ev.Type {
-	case termbox.EventKey, termbox.EventMouse:
+func (g *Gui) handleEvent(ev *event) error {
+	switch ev.typ {
+	case eventKey, eventMouse:
 		return g.onKey(ev)
-	case termbox.EventResize:
+	case eventPaste:
+		return g.onPaste(ev)
+	case eventResize:
 		return g.onResize(ev)
-	case termbox.EventError:
-		return ev.Err
+	case eventError:
+		return ev.err
 	default:
 		return nil
 	}
@@ -458,33 +549,36 @@ func (g *Gui) flush() error {
 		}
 	}
 	g.maxX, g.maxY = maxX, maxY
+	g.resetFrameLines()
 
 	for _, m := range g.managers {
 		if err := m.Layout(g); err != nil {
 			return err
 		}
 	}
+	if err := g.layoutDialogs(); err != nil {
+		return err
+	}
+	g.sortViews()
+	if err := g.execViewCallbacks(); err != nil {
+		return err
+	}
 	for _, v := range g.views {
+		if !v.Visible {
+			continue
+		}
 		if v.Frame {
-			var fgColor, bgColor Attribute
-			if g.Highlight && v == g.currentView {
-				fgColor = g.SelFgColor
-				bgColor = g.SelBgColor
-			} else {
-				fgColor = v.FrameFgColor
-				bgColor = v.FrameBgColor
+			if err := g.drawFrameEdges(v); err != nil {
+				return err
 			}
-
-			if err := g.drawFrameEdges(v, fgColor, bgColor); err != nil {
+			if err := g.drawFrameCorners(v); err != nil {
 				return err
 			}
-			if err := g.drawFrameCorners(v, fgColor, bgColor); err != nil {
+			if err := g.drawTitle(v); err != nil {
 				return err
 			}
-			if v.Title != "" {
-				if err := g.drawTitle(v, v.TitleFgColor, v.TitleBgColor); err != nil {
-					return err
-				}
+			if err := g.drawFooter(v); err != nil {
+				return err
 			}
 		}
 		if err := g.draw(v); err != nil {
@@ -496,23 +590,20 @@ func (g *Gui) flush() error {
 }
 
 // drawFrameEdges draws the horizontal and vertical edges of a view.
-func (g *Gui) drawFrameEdges(v *View, fgColor, bgColor Attribute) error {
-	runeH, runeV := '─', '│'
-	if g.ASCII {
-		runeH, runeV = '-', '|'
-	}
+func (g *Gui) drawFrameEdges(v *View) error {
+	style := g.frameStyle(v)
 
 	for x := v.x0 + 1; x < v.x1 && x < g.maxX; x++ {
 		if x < 0 {
 			continue
 		}
-		if v.y0 > -1 && v.y0 < g.maxY {
-			if err := g.SetRune(x, v.y0, runeH, fgColor, bgColor); err != nil {
+		if !style.HideTop && v.y0 > -1 && v.y0 < g.maxY {
+			if err := g.setFrameRune(v, x, v.y0, lineH); err != nil {
 				return err
 			}
 		}
-		if v.y1 > -1 && v.y1 < g.maxY {
-			if err := g.SetRune(x, v.y1, runeH, fgColor, bgColor); err != nil {
+		if !style.HideBottom && v.y1 > -1 && v.y1 < g.maxY {
+			if err := g.setFrameRune(v, x, v.y1, lineH); err != nil {
 				return err
 			}
 		}
@@ -521,13 +612,13 @@ func (g *Gui) drawFrameEdges(v *View, fgColor, bgColor Attribute) error {
 		if y < 0 {
 			continue
 		}
-		if v.x0 > -1 && v.x0 < g.maxX {
-			if err := g.SetRune(v.x0, y, runeV, fgColor, bgColor); err != nil {
+		if !style.HideLeft && v.x0 > -1 && v.x0 < g.maxX {
+			if err := g.setFrameRune(v, v.x0, y, lineV); err != nil {
 				return err
 			}
 		}
-		if v.x1 > -1 && v.x1 < g.maxX {
-			if err := g.SetRune(v.x1, y, runeV, fgColor, bgColor); err != nil {
+		if !style.HideRight && v.x1 > -1 && v.x1 < g.maxX {
+			if err := g.setFrameRune(v, v.x1, y, lineV); err != nil {
 				return err
 			}
 		}
@@ -535,21 +626,39 @@ func (g *Gui) drawFrameEdges(v *View, fgColor, bgColor Attribute) error {
 	return nil
 }
 
-// drawFrameCorners draws the corners of the view.
-func (g *Gui) drawFrameCorners(v *View, fgColor, bgColor Attribute) error {
-	runeTL, runeTR, runeBL, runeBR := '┌', '┐', '└', '┘'
-	if g.ASCII {
-		runeTL, runeTR, runeBL, runeBR = '+', '+', '+', '+'
+// drawFrameCorners draws the corners of the view. Corners shared with the
+// frames of other views are drawn as junctions, and corners of hidden sides
+// continue the visible ones.
+func (g *Gui) drawFrameCorners(v *View) error {
+	style := g.frameStyle(v)
+
+	var top, bottom, left, right uint8
+	if !style.HideTop {
+		top = lineLeft | lineRight
+	}
+	if !style.HideBottom {
+		bottom = lineLeft | lineRight
+	}
+	if !style.HideLeft {
+		left = lineUp | lineDown
+	}
+	if !style.HideRight {
+		right = lineUp | lineDown
 	}
 
 	corners := []struct {
-		x, y int
-		ch   rune
-	}{{v.x0, v.y0, runeTL}, {v.x1, v.y0, runeTR}, {v.x0, v.y1, runeBL}, {v.x1, v.y1, runeBR}}
+		x, y  int
+		lines uint8
+	}{
+		{v.x0, v.y0, top&lineRight | left&lineDown},
+		{v.x1, v.y0, top&lineLeft | right&lineDown},
+		{v.x0, v.y1, bottom&lineRight | left&lineUp},
+		{v.x1, v.y1, bottom&lineLeft | right&lineUp},
+	}
 
 	for _, c := range corners {
-		if c.x >= 0 && c.y >= 0 && c.x < g.maxX && c.y < g.maxY {
-			if err := g.SetRune(c.x, c.y, c.ch, fgColor, bgColor); err != nil {
+		if c.lines != 0 && c.x >= 0 && c.y >= 0 && c.x < g.maxX && c.y < g.maxY {
+			if err := g.setFrameRune(v, c.x, c.y, c.lines); err != nil {
 				return err
 			}
 		}
@@ -557,30 +666,10 @@ func (g *Gui) drawFrameCorners(v *View, fgColor, bgColor Attribute) error {
 	return nil
 }
 
-// drawTitle draws the title of the view.
-func (g *Gui) drawTitle(v *View, fgColor, bgColor Attribute) error {
-	if v.y0 < 0 || v.y0 >= g.maxY {
-		return nil
-	}
-
-	for i, ch := range v.Title {
-		x := v.x0 + i + 2
-		if x < 0 {
-			continue
-		} else if x > v.x1-2 || x >= g.maxX {
-			break
-		}
-		if err := g.SetRune(x, v.y0, ch, fgColor, bgColor); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 // draw manages the cursor and calls the draw function of a view.
 func (g *Gui) draw(v *View) error {
 	if g.Cursor {
-		if curview := g.currentView; curview != nil {
+		if curview := g.focusedView(); curview != nil {
 			vMaxX, vMaxY := curview.Size()
 			if curview.cx < 0 {
 				curview.cx = 0
@@ -594,7 +683,8 @@ func (g *Gui) draw(v *View) error {
 			}
 
 			gMaxX, gMaxY := g.Size()
-			cx, cy := curview.x0+curview.cx+1, curview.y0+curview.cy+1
+			x0, y0 := curview.origin()
+			cx, cy := x0+curview.cx, y0+curview.cy
 			if cx >= 0 && cx < gMaxX && cy >= 0 && cy < gMaxY {
 				termbox.SetCursor(cx, cy)
 			} else {
@@ -606,54 +696,110 @@ func (g *Gui) draw(v *View) error {
 	}
 
 	v.clearRunes()
+	g.clearFrameLines(v.x0+1, v.y0+1, v.x1-1, v.y1-1)
 	if err := v.draw(); err != nil {
 		return err
 	}
-	return nil
+	return g.drawScrollbars(v)
 }
 
 // onKey manages key-press events. A keybinding handler is called when
 // a key-press or mouse event satisfies a configured keybinding. Furthermore,
-// currentView's internal buffer is modified if currentView.Editable is true.
-func (g *Gui) onKey(ev *termbox.Event) error {
-	switch ev.Type {
-	case termbox.EventKey:
-		matched, err := g.execKeybindings(g.currentView, ev)
+// currentView's internal buffer is modified if currentView.Editable is true
+// and the current mode allows edition.
+func (g *Gui) onKey(ev *event) error {
+	if d := g.activeDialog(); d != nil {
+		if ev.typ == eventMouse {
+			return g.onDialogMouse(d, ev)
+		}
+		return g.onDialogKey(d, ev)
+	}
+
+	switch ev.typ {
+	case eventKey:
+		v := g.focusedView()
+		matched, err := g.execKeybindings(v, ev)
 		if err != nil {
 			return err
 		}
 		if matched {
 			break
 		}
-		if g.currentView != nil && g.currentView.Editable && g.currentView.Editor != nil {
-			g.currentView.Editor.Edit(g.currentView, Key(ev.Key), ev.Ch, Modifier(ev.Mod))
-		}
-	case termbox.EventMouse:
-		mx, my := ev.MouseX, ev.MouseY
-		v, err := g.ViewByPosition(mx, my)
-		if err != nil {
-			break
+		if v != nil && v.Editable && v.Editor != nil && g.editModes[g.mode] {
+			v.Editor.Edit(v, ev.key, ev.ch, ev.mod)
 		}
-		if err := v.SetCursor(mx-v.x0-1, my-v.y0-1); err != nil {
-			return err
+	case eventMouse:
+		return g.onMouse(ev)
+	}
+
+	return nil
+}
+
+// focusedView returns the view that receives the keyboard input: the current
+// view, unless it is hidden.
+func (g *Gui) focusedView() *View {
+	if g.currentView == nil || !g.currentView.Visible {
+		return nil
+	}
+	return g.currentView
+}
+
+// onPaste manages paste events. The pasted text is passed to the Editor of
+// currentView if currentView.Editable is true and the current mode allows
+// edition. Keybindings are never triggered by pasted text.
+func (g *Gui) onPaste(ev *event) error {
+	v := g.focusedView()
+	if v == nil || !v.Editable || v.Editor == nil || !g.editModes[g.mode] {
+		return nil
+	}
+
+	if pe, ok := v.Editor.(PasteEditor); ok {
+		pe.Paste(v, ev.paste)
+		return nil
+	}
+	for _, ch := range ev.paste {
+		// keep the view's lines up to date, as they are not redrawn
+		// between runes
+		if maxX, _ := v.Size(); v.tainted && maxX > 0 {
+			v.updateViewLines(maxX)
 		}
-		if _, err := g.execKeybindings(v, ev); err != nil {
-			return err
+
+		switch ch {
+		case '\n':
+			v.Editor.Edit(v, KeyEnter, 0, ModNone)
+		case '\t':
+			v.Editor.Edit(v, KeyTab, 0, ModNone)
+		case ' ':
+			v.Editor.Edit(v, KeySpace, 0, ModNone)
+		default:
+			v.Editor.Edit(v, 0, ch, ModNone)
 		}
 	}
-
 	return nil
 }
 
 // execKeybindings executes the keybinding handlers that match the passed view
 // and event. The value of matched is true if there is a match and no errors.
-func (g *Gui) execKeybindings(v *View, ev *termbox.Event) (matched bool, err error) {
+func (g *Gui) execKeybindings(v *View, ev *event) (matched bool, err error) {
 	matched = false
+	if v != nil {
+		for _, kb := range v.keybindings {
+			if kb.handler == nil {
+				continue
+			}
+			if kb.matchKeypress(ev.key, ev.ch, ev.mod) && kb.matchMode(g.mode) {
+				if err := kb.handler(g, v); err != nil {
+					return false, err
+				}
+				matched = true
+			}
+		}
+	}
 	for _, kb := range g.keybindings {
 		if kb.handler == nil {
 			continue
 		}
-		if kb.matchKeypress(Key(ev.Key), ev.Ch, Modifier(ev.Mod)) && kb.matchView(v) {
+		if kb.matchKeypress(ev.key, ev.ch, ev.mod) && kb.matchView(v) && kb.matchMode(g.mode) {
 			if err := kb.handler(g, v); err != nil {
 				return false, err
 			}
@@ -663,8 +809,33 @@ func (g *Gui) execKeybindings(v *View, ev *termbox.Event) (matched bool, err err
 	return matched, nil
 }
 
+// execViewCallbacks calls the OnResize and OnChange handlers of the views
+// whose size or content have changed since the last flush.
+func (g *Gui) execViewCallbacks() error {
+	for _, v := range g.views {
+		w, h := v.Size()
+		resized := v.sized && (w != v.width || h != v.height)
+		v.sized, v.width, v.height = true, w, h
+		if resized && v.OnResize != nil {
+			if err := v.OnResize(g, v, w, h); err != nil {
+				return err
+			}
+		}
+
+		if v.changed {
+			v.changed = false
+			if v.OnChange != nil {
+				if err := v.OnChange(g, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // onResize manages resize events. It executes the resize handler if it's set.
-func (g *Gui) onResize(ev *termbox.Event) error {
+func (g *Gui) onResize(ev *event) error {
 	if g.resizeHandler != nil {
 		maxX, maxY := g.Size()
 		return g.resizeHandler(g, maxX, maxY)
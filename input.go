@@ -0,0 +1,372 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode"
+
+	termbox "github.com/nsf/termbox-go"
+)
+
+// eventType represents the type of an event.
+type eventType uint8
+
+// Event types.
+const (
+	eventNone eventType = iota
+	eventKey
+	eventMouse
+	eventResize
+	eventError
+	eventPaste
+)
+
+// event represents an input event. Events are reported by termbox or
+// decoded by gotui from the sequences that termbox does not understand.
+type event struct {
+	typ            eventType
+	key            Key
+	ch             rune
+	mod            Modifier
+	mouseX, mouseY int
+	err            error
+	paste          string
+}
+
+// newEvent converts a termbox event into an event.
+func newEvent(tev termbox.Event) event {
+	ev := event{
+		key:    Key(tev.Key),
+		ch:     tev.Ch,
+		mod:    Modifier(tev.Mod),
+		mouseX: tev.MouseX,
+		mouseY: tev.MouseY,
+		err:    tev.Err,
+	}
+	switch tev.Type {
+	case termbox.EventKey:
+		ev.typ = eventKey
+	case termbox.EventMouse:
+		ev.typ = eventMouse
+	case termbox.EventResize:
+		ev.typ = eventResize
+	case termbox.EventError:
+		ev.typ = eventError
+	}
+	return ev
+}
+
+// parseStatus is the result of decoding an input sequence.
+type parseStatus int
+
+const (
+	parseOK         parseStatus = iota // the sequence has been decoded
+	parseIncomplete                    // more input is needed
+	parseUnknown                       // the sequence must be parsed by termbox
+)
+
+// maxCSILength is the maximum length of a CSI sequence handled by gotui.
+const maxCSILength = 64
+
+// Sequences delimiting pasted text when bracketed paste is enabled.
+var (
+	pasteStart = []byte("\x1b[200~")
+	pasteEnd   = []byte("\x1b[201~")
+)
+
+// pasteReplacer normalizes the line endings of pasted text.
+var pasteReplacer = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// Keys reported by xterm-like terminals as CSI 1 ; modifier <final>.
+var csiFinalKeys = map[byte]Key{
+	'A': KeyArrowUp,
+	'B': KeyArrowDown,
+	'C': KeyArrowRight,
+	'D': KeyArrowLeft,
+	'H': KeyHome,
+	'F': KeyEnd,
+	'P': KeyF1,
+	'Q': KeyF2,
+	'R': KeyF3,
+	'S': KeyF4,
+}
+
+// Keys reported by xterm-like terminals as CSI number ; modifier ~.
+var csiTildeKeys = map[int]Key{
+	1:  KeyHome,
+	2:  KeyInsert,
+	3:  KeyDelete,
+	4:  KeyEnd,
+	5:  KeyPgup,
+	6:  KeyPgdn,
+	7:  KeyHome,
+	8:  KeyEnd,
+	11: KeyF1,
+	12: KeyF2,
+	13: KeyF3,
+	14: KeyF4,
+	15: KeyF5,
+	17: KeyF6,
+	18: KeyF7,
+	19: KeyF8,
+	20: KeyF9,
+	21: KeyF10,
+	23: KeyF11,
+	24: KeyF12,
+}
+
+// parseInput decodes the input sequence at the beginning of b. It returns
+// the decoded event and the number of bytes consumed. Only the sequences
+// that termbox cannot decode are handled, for the rest parseUnknown is
+// returned. A decoded event of type eventNone must be discarded.
+func parseInput(b []byte) (ev event, n int, status parseStatus) {
+	if len(b) == 0 || b[0] != 0x1b {
+		return event{}, 0, parseUnknown
+	}
+	if len(b) == 1 {
+		return event{}, 0, parseIncomplete
+	}
+	switch b[1] {
+	case '[':
+		if bytes.HasPrefix(b, pasteStart) {
+			return parsePaste(b)
+		}
+		return parseCSI(b)
+	case 'O':
+		if len(b) == 2 {
+			return event{}, 0, parseIncomplete
+		}
+	}
+	return event{}, 0, parseUnknown
+}
+
+// parsePaste decodes the pasted text at the beginning of b, which must
+// start with pasteStart. The paste is incomplete until pasteEnd is found.
+func parsePaste(b []byte) (ev event, n int, status parseStatus) {
+	end := bytes.Index(b, pasteEnd)
+	if end == -1 {
+		return event{}, 0, parseIncomplete
+	}
+	text := string(b[len(pasteStart):end])
+	ev = event{typ: eventPaste, paste: pasteReplacer.Replace(text)}
+	return ev, end + len(pasteEnd), parseOK
+}
+
+// parseCSI decodes the CSI sequence at the beginning of b, which must start
+// with "ESC [".
+func parseCSI(b []byte) (ev event, n int, status parseStatus) {
+	i := 2
+	if i < len(b) && b[i] == 'M' {
+		// X10 mouse encoding, handled by termbox
+		return event{}, 0, parseUnknown
+	}
+	for i < len(b) && b[i] >= 0x30 && b[i] <= 0x3f {
+		i++
+	}
+	for i < len(b) && b[i] >= 0x20 && b[i] <= 0x2f {
+		i++
+	}
+	if i >= maxCSILength {
+		return event{}, 0, parseUnknown
+	}
+	if i == len(b) {
+		return event{}, 0, parseIncomplete
+	}
+
+	final := b[i]
+	if final < 0x40 || final > 0x7e {
+		return event{}, 0, parseUnknown
+	}
+	n = i + 1
+
+	var params []string
+	if i > 2 {
+		params = strings.Split(string(b[2:i]), ";")
+	}
+
+	switch {
+	case (final == 'M' || final == 'm') && b[2] == '<':
+		return parseSGRMouse(params, final == 'm'), n, parseOK
+	case final == 'Z' && len(params) == 0:
+		// Shift+Tab
+		return event{typ: eventKey, key: KeyTab, mod: ModShift}, n, parseOK
+	case final == 'u':
+		// kitty keyboard protocol: CSI code ; modifiers u
+		if len(params) == 0 {
+			return event{}, 0, parseUnknown
+		}
+		code, err := csiParam(params[0])
+		if err != nil {
+			return event{}, 0, parseUnknown
+		}
+		var mod Modifier
+		if len(params) > 1 {
+			mod = csiModifier(params[1])
+		}
+		return keyEvent(code, mod), n, parseOK
+	case final == '~' && len(params) == 1 && params[0] == "201":
+		// unmatched end of paste
+		return event{}, n, parseOK
+	case final == '~':
+		if len(params) < 2 {
+			return event{}, 0, parseUnknown
+		}
+		num, err := csiParam(params[0])
+		if err != nil {
+			return event{}, 0, parseUnknown
+		}
+		if num == 27 && len(params) == 3 {
+			// xterm modifyOtherKeys: CSI 27 ; modifiers ; code ~
+			code, err := csiParam(params[2])
+			if err != nil {
+				return event{}, 0, parseUnknown
+			}
+			return keyEvent(code, csiModifier(params[1])), n, parseOK
+		}
+		key, ok := csiTildeKeys[num]
+		if !ok {
+			return event{}, 0, parseUnknown
+		}
+		return event{typ: eventKey, key: key, mod: csiModifier(params[1])}, n, parseOK
+	default:
+		key, ok := csiFinalKeys[final]
+		if !ok || len(params) < 2 {
+			return event{}, 0, parseUnknown
+		}
+		return event{typ: eventKey, key: key, mod: csiModifier(params[1])}, n, parseOK
+	}
+}
+
+// parseSGRMouse decodes the parameters of a mouse event reported using the
+// SGR (1006) encoding: CSI < button ; x ; y M, or m for releases. The event
+// is reported like termbox would do it, but including the modifiers.
+func parseSGRMouse(params []string, release bool) event {
+	if len(params) != 3 {
+		return event{}
+	}
+	b, err := csiParam(params[0][1:])
+	if err != nil {
+		return event{}
+	}
+	x, err := csiParam(params[1])
+	if err != nil {
+		return event{}
+	}
+	y, err := csiParam(params[2])
+	if err != nil {
+		return event{}
+	}
+
+	ev := event{typ: eventMouse, mouseX: x - 1, mouseY: y - 1}
+	switch {
+	case b&64 != 0 && b&3 == 0:
+		ev.key = MouseWheelUp
+	case b&64 != 0 && b&3 == 1:
+		ev.key = MouseWheelDown
+	case b&64 != 0 || b&128 != 0:
+		// horizontal wheel and extra buttons are not supported
+		return event{}
+	case b&3 == 0:
+		ev.key = MouseLeft
+	case b&3 == 1:
+		ev.key = MouseMiddle
+	case b&3 == 2:
+		ev.key = MouseRight
+	default:
+		ev.key = MouseRelease
+	}
+	if release {
+		ev.key = MouseRelease
+	}
+
+	if b&4 != 0 {
+		ev.mod |= ModShift
+	}
+	if b&8 != 0 {
+		ev.mod |= ModAlt
+	}
+	if b&16 != 0 {
+		ev.mod |= ModCtrl
+	}
+	if b&32 != 0 {
+		ev.mod |= ModMotion
+	}
+	return ev
+}
+
+// csiParam returns the numeric value of a CSI parameter, ignoring its
+// sub-parameters.
+func csiParam(param string) (int, error) {
+	if i := strings.IndexByte(param, ':'); i != -1 {
+		param = param[:i]
+	}
+	return strconv.Atoi(param)
+}
+
+// csiModifier converts a CSI modifier parameter, encoded as 1 plus a
+// bitmask, into a Modifier.
+func csiModifier(param string) Modifier {
+	m, err := csiParam(param)
+	if err != nil || m < 1 {
+		return ModNone
+	}
+	m--
+
+	var mod Modifier
+	if m&1 != 0 {
+		mod |= ModShift
+	}
+	if m&2 != 0 {
+		mod |= ModAlt
+	}
+	if m&4 != 0 {
+		mod |= ModCtrl
+	}
+	if m&(8|32) != 0 {
+		mod |= ModMeta
+	}
+	return mod
+}
+
+// keyEvent returns the key event corresponding to a unicode key code and
+// its modifiers. Whenever possible, the event is reported like termbox
+// would do it, so existing keybindings keep working. For instance, Ctrl+A
+// is reported as KeyCtrlA and Shift+a as 'A'. Shift is only applied to
+// letters, since the character produced by other keys depends on the
+// keyboard layout: Shift+1 is reported as '1' with ModShift.
+func keyEvent(code int, mod Modifier) event {
+	ev := event{typ: eventKey, mod: mod}
+
+	switch {
+	case code == 9:
+		ev.key = KeyTab
+	case code == 13:
+		ev.key = KeyEnter
+	case code == 27:
+		ev.key = KeyEsc
+	case code == 127:
+		ev.key = KeyBackspace2
+	case code == ' ' && mod&ModCtrl != 0:
+		ev.key = KeyCtrlSpace
+		ev.mod &^= ModCtrl
+	case code == ' ':
+		ev.key = KeySpace
+	case code >= 'a' && code <= 'z' && mod&ModCtrl != 0 && mod&^(ModCtrl|ModAlt) == 0:
+		ev.key = KeyCtrlA + Key(code-'a')
+		ev.mod &^= ModCtrl
+	case code >= 0xe000 && code <= 0xf8ff:
+		// functional keys in the private use area are not supported
+		return event{}
+	case mod == ModShift && unicode.IsLetter(rune(code)):
+		ev.ch = unicode.ToUpper(rune(code))
+		ev.mod = ModNone
+	default:
+		ev.ch = rune(code)
+	}
+	return ev
+}
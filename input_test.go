@@ -0,0 +1,182 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import "testing"
+
+func TestParseInput(t *testing.T) {
+	key := func(k Key, mod Modifier) event {
+		return event{typ: eventKey, key: k, mod: mod}
+	}
+	char := func(ch rune, mod Modifier) event {
+		return event{typ: eventKey, ch: ch, mod: mod}
+	}
+	mouse := func(k Key, x, y int, mod Modifier) event {
+		return event{typ: eventMouse, key: k, mouseX: x, mouseY: y, mod: mod}
+	}
+
+	tests := []struct {
+		in     string
+		ev     event
+		n      int
+		status parseStatus
+	}{
+		// left to termbox
+		{"", event{}, 0, parseUnknown},
+		{"a", event{}, 0, parseUnknown},
+		{"\x1b[M !!", event{}, 0, parseUnknown},
+		{"\x1bOP", event{}, 0, parseUnknown},
+		{"\x1bx", event{}, 0, parseUnknown},
+		{"\x1b[A", event{}, 0, parseUnknown},
+		{"\x1b[3~", event{}, 0, parseUnknown},
+		{"\x1b[1;5X", event{}, 0, parseUnknown},
+		{"\x1b[99;5~", event{}, 0, parseUnknown},
+		{"\x1b[u", event{}, 0, parseUnknown},
+
+		// truncated sequences
+		{"\x1b", event{}, 0, parseIncomplete},
+		{"\x1b[", event{}, 0, parseIncomplete},
+		{"\x1bO", event{}, 0, parseIncomplete},
+		{"\x1b[1;5", event{}, 0, parseIncomplete},
+		{"\x1b[<0;10", event{}, 0, parseIncomplete},
+		{"\x1b[27;5;97", event{}, 0, parseIncomplete},
+
+		// modified keys
+		{"\x1b[1;2A", key(KeyArrowUp, ModShift), 6, parseOK},
+		{"\x1b[1;3B", key(KeyArrowDown, ModAlt), 6, parseOK},
+		{"\x1b[1;5C", key(KeyArrowRight, ModCtrl), 6, parseOK},
+		{"\x1b[1;9D", key(KeyArrowLeft, ModMeta), 6, parseOK},
+		{"\x1b[1;8H", key(KeyHome, ModShift|ModAlt|ModCtrl), 6, parseOK},
+		{"\x1b[1;5Pxyz", key(KeyF1, ModCtrl), 6, parseOK},
+		{"\x1b[3;2~", key(KeyDelete, ModShift), 6, parseOK},
+		{"\x1b[5;5~", key(KeyPgup, ModCtrl), 6, parseOK},
+		{"\x1b[15;3~", key(KeyF5, ModAlt), 7, parseOK},
+		{"\x1b[Z", key(KeyTab, ModShift), 3, parseOK},
+
+		// kitty keyboard protocol
+		{"\x1b[97;5u", key(KeyCtrlA, ModNone), 7, parseOK},
+		{"\x1b[122;5u", key(KeyCtrlZ, ModNone), 8, parseOK},
+		{"\x1b[97;7u", key(KeyCtrlA, ModAlt), 7, parseOK},
+		{"\x1b[97;2u", char('A', ModNone), 7, parseOK},
+		{"\x1b[97;3u", char('a', ModAlt), 7, parseOK},
+		{"\x1b[97;6u", char('a', ModShift|ModCtrl), 7, parseOK},
+		{"\x1b[97u", char('a', ModNone), 5, parseOK},
+		{"\x1b[97:65;2u", char('A', ModNone), 10, parseOK},
+		{"\x1b[49;2u", char('1', ModShift), 7, parseOK},
+		{"\x1b[9;2u", key(KeyTab, ModShift), 6, parseOK},
+		{"\x1b[13;5u", key(KeyEnter, ModCtrl), 7, parseOK},
+		{"\x1b[27u", key(KeyEsc, ModNone), 5, parseOK},
+		{"\x1b[127;3u", key(KeyBackspace2, ModAlt), 8, parseOK},
+		{"\x1b[32;5u", key(KeyCtrlSpace, ModNone), 7, parseOK},
+		{"\x1b[32u", key(KeySpace, ModNone), 5, parseOK},
+		{"\x1b[57344u", event{}, 8, parseOK},
+
+		// xterm modifyOtherKeys
+		{"\x1b[27;5;97~", key(KeyCtrlA, ModNone), 10, parseOK},
+		{"\x1b[27;2;97~", char('A', ModNone), 10, parseOK},
+		{"\x1b[27;5;13~", key(KeyEnter, ModCtrl), 10, parseOK},
+		{"\x1b[27;3;49~", char('1', ModAlt), 10, parseOK},
+		{"\x1b[27;2;49~", char('1', ModShift), 10, parseOK},
+
+		// SGR mouse
+		{"\x1b[<0;10;5M", mouse(MouseLeft, 9, 4, ModNone), 10, parseOK},
+		{"\x1b[<1;1;1M", mouse(MouseMiddle, 0, 0, ModNone), 9, parseOK},
+		{"\x1b[<2;1;1M", mouse(MouseRight, 0, 0, ModNone), 9, parseOK},
+		{"\x1b[<0;10;5m", mouse(MouseRelease, 9, 4, ModNone), 10, parseOK},
+		{"\x1b[<3;1;1M", mouse(MouseRelease, 0, 0, ModNone), 9, parseOK},
+		{"\x1b[<64;1;1M", mouse(MouseWheelUp, 0, 0, ModNone), 10, parseOK},
+		{"\x1b[<65;1;1M", mouse(MouseWheelDown, 0, 0, ModNone), 10, parseOK},
+		{"\x1b[<66;1;1M", event{}, 10, parseOK},
+		{"\x1b[<4;1;1M", mouse(MouseLeft, 0, 0, ModShift), 9, parseOK},
+		{"\x1b[<8;1;1M", mouse(MouseLeft, 0, 0, ModAlt), 9, parseOK},
+		{"\x1b[<16;1;1M", mouse(MouseLeft, 0, 0, ModCtrl), 10, parseOK},
+		{"\x1b[<32;3;2M", mouse(MouseLeft, 2, 1, ModMotion), 10, parseOK},
+
+		// bracketed paste
+		{"\x1b[200~", event{}, 0, parseIncomplete},
+		{"\x1b[200~hello", event{}, 0, parseIncomplete},
+		{"\x1b[200~hello\x1b[201", event{}, 0, parseIncomplete},
+		{"\x1b[200~hello\x1b[201~", event{typ: eventPaste, paste: "hello"}, 17, parseOK},
+		{"\x1b[200~a\r\nb\rc\x1b[201~x", event{typ: eventPaste, paste: "a\nb\nc"}, 18, parseOK},
+		{"\x1b[200~\x1b[A\x1b[201~", event{typ: eventPaste, paste: "\x1b[A"}, 15, parseOK},
+		{"\x1b[201~", event{}, 6, parseOK},
+	}
+	for _, tt := range tests {
+		ev, n, status := parseInput([]byte(tt.in))
+		if ev != tt.ev || n != tt.n || status != tt.status {
+			t.Errorf("parseInput(%q) = %+v, %d, %d; want %+v, %d, %d",
+				tt.in, ev, n, status, tt.ev, tt.n, tt.status)
+		}
+	}
+}
+
+func TestParseInputSplitPaste(t *testing.T) {
+	// the paste is only decoded when all of it has been read
+	in := []byte("\x1b[200~first line\nsecond line\x1b[201~")
+	for i := 1; i < len(in); i++ {
+		if _, _, status := parseInput(in[:i]); status != parseIncomplete {
+			t.Fatalf("parseInput(%q) = %d, want parseIncomplete", in[:i], status)
+		}
+	}
+	ev, n, status := parseInput(in)
+	if status != parseOK || n != len(in) || ev.paste != "first line\nsecond line" {
+		t.Errorf("parseInput(%q) = %+v, %d, %d", in, ev, n, status)
+	}
+}
+
+func TestCSIModifier(t *testing.T) {
+	tests := []struct {
+		param string
+		mod   Modifier
+	}{
+		{"", ModNone},
+		{"x", ModNone},
+		{"0", ModNone},
+		{"1", ModNone},
+		{"2", ModShift},
+		{"3", ModAlt},
+		{"5", ModCtrl},
+		{"9", ModMeta},
+		{"33", ModMeta},
+		{"16", ModShift | ModAlt | ModCtrl | ModMeta},
+		{"5:1", ModCtrl},
+	}
+	for _, tt := range tests {
+		if mod := csiModifier(tt.param); mod != tt.mod {
+			t.Errorf("csiModifier(%q) = %v, want %v", tt.param, mod, tt.mod)
+		}
+	}
+}
+
+func TestKeyEvent(t *testing.T) {
+	tests := []struct {
+		code int
+		mod  Modifier
+		ev   event
+	}{
+		{'a', ModNone, event{typ: eventKey, ch: 'a'}},
+		{'a', ModCtrl, event{typ: eventKey, key: KeyCtrlA}},
+		{'z', ModCtrl, event{typ: eventKey, key: KeyCtrlZ}},
+		{'a', ModCtrl | ModAlt, event{typ: eventKey, key: KeyCtrlA, mod: ModAlt}},
+		{'a', ModCtrl | ModShift, event{typ: eventKey, ch: 'a', mod: ModCtrl | ModShift}},
+		{'a', ModShift, event{typ: eventKey, ch: 'A'}},
+		{'é', ModShift, event{typ: eventKey, ch: 'É'}},
+		{'1', ModShift, event{typ: eventKey, ch: '1', mod: ModShift}},
+		{'-', ModShift, event{typ: eventKey, ch: '-', mod: ModShift}},
+		{'a', ModAlt, event{typ: eventKey, ch: 'a', mod: ModAlt}},
+		{9, ModNone, event{typ: eventKey, key: KeyTab}},
+		{13, ModShift, event{typ: eventKey, key: KeyEnter, mod: ModShift}},
+		{27, ModNone, event{typ: eventKey, key: KeyEsc}},
+		{127, ModCtrl, event{typ: eventKey, key: KeyBackspace2, mod: ModCtrl}},
+		{' ', ModCtrl, event{typ: eventKey, key: KeyCtrlSpace}},
+		{' ', ModNone, event{typ: eventKey, key: KeySpace}},
+		{0xe000, ModNone, event{}},
+	}
+	for _, tt := range tests {
+		if ev := keyEvent(tt.code, tt.mod); ev != tt.ev {
+			t.Errorf("keyEvent(%d, %v) = %+v, want %+v", tt.code, tt.mod, ev, tt.ev)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package gotui
+
+import (
+	"bytes"
+	"os"
+	"time"
+
+	termbox "github.com/nsf/termbox-go"
+)
+
+// escDelay is the time to wait for the rest of an incomplete escape
+// sequence before decoding it anyway.
+const escDelay = 50 * time.Millisecond
+
+// Control sequences used to configure the terminal input.
+const (
+	seqEnableExtendedKeys  = "\x1b[>1u\x1b[>4;2m"
+	seqDisableExtendedKeys = "\x1b[<u\x1b[>4m"
+	seqEnableMouseMotion   = "\x1b[?1003h"
+	seqDisableMouseMotion  = "\x1b[?1003l"
+	seqEnablePaste         = "\x1b[?2004h"
+	seqDisablePaste        = "\x1b[?2004l"
+)
+
+// pollEvents reads the raw terminal input, decodes it and sends the
+// resulting events to the events channel. It never returns.
+func (g *Gui) pollEvents() {
+	chunks := make(chan []byte)
+	go func() {
+		data := make([]byte, 4096)
+		for {
+			tev := termbox.PollRawEvent(data)
+			switch tev.Type {
+			case termbox.EventRaw:
+				b := make([]byte, tev.N)
+				copy(b, data[:tev.N])
+				chunks <- b
+			case termbox.EventResize, termbox.EventError:
+				g.events <- newEvent(tev)
+			}
+		}
+	}()
+
+	var (
+		buf     []byte
+		timeout <-chan time.Time
+	)
+	for {
+		select {
+		case b := <-chunks:
+			buf = g.decodeInput(append(buf, b...), false)
+		case <-timeout:
+			buf = g.decodeInput(buf, true)
+		}
+
+		// pasted text is only complete when the end of the paste arrives
+		timeout = nil
+		if len(buf) > 0 && !bytes.HasPrefix(buf, pasteStart) {
+			timeout = time.After(escDelay)
+		}
+	}
+}
+
+// decodeInput sends the events found in buf to the events channel and
+// returns the bytes that belong to an incomplete sequence. If flush is true,
+// incomplete sequences are decoded anyway.
+func (g *Gui) decodeInput(buf []byte, flush bool) []byte {
+	for len(buf) > 0 {
+		ev, n, status := parseInput(buf)
+		if status == parseIncomplete && !flush {
+			return buf
+		}
+		if status == parseOK {
+			if ev.typ != eventNone {
+				g.events <- ev
+			}
+			buf = buf[n:]
+			continue
+		}
+
+		tev := termbox.ParseEvent(buf)
+		if tev.N == 0 {
+			if !flush {
+				return buf
+			}
+			tev.N = 1
+		}
+		if tev.Type != termbox.EventNone {
+			g.events <- newEvent(tev)
+		}
+		buf = buf[tev.N:]
+	}
+	return nil
+}
+
+// setInputModes enables the terminal input features requested by the GUI.
+func (g *Gui) setInputModes() error {
+	seq := ""
+	if g.ExtendedKeys {
+		seq += seqEnableExtendedKeys
+	}
+	if g.Mouse && g.MouseMotion {
+		seq += seqEnableMouseMotion
+	}
+	if g.BracketedPaste {
+		seq += seqEnablePaste
+	}
+	if seq == "" {
+		return nil
+	}
+	return writeTerminal(seq)
+}
+
+// resetInputModes disables the terminal input features enabled by
+// setInputModes.
+func (g *Gui) resetInputModes() error {
+	seq := ""
+	if g.ExtendedKeys {
+		seq += seqDisableExtendedKeys
+	}
+	if g.Mouse && g.MouseMotion {
+		seq += seqDisableMouseMotion
+	}
+	if g.BracketedPaste {
+		seq += seqDisablePaste
+	}
+	if seq == "" {
+		return nil
+	}
+	return writeTerminal(seq)
+}
+
+// writeTerminal writes a raw control sequence to the terminal.
+func writeTerminal(seq string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	_, err = tty.WriteString(seq)
+	return err
+}
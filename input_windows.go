@@ -0,0 +1,29 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package gotui
+
+import termbox "github.com/nsf/termbox-go"
+
+// pollEvents sends the events reported by termbox to the events channel.
+// Raw input is not available on Windows, so extended input sequences are
+// not decoded. It never returns.
+func (g *Gui) pollEvents() {
+	for {
+		g.events <- newEvent(termbox.PollEvent())
+	}
+}
+
+// setInputModes is a no-op on Windows.
+func (g *Gui) setInputModes() error {
+	return nil
+}
+
+// resetInputModes is a no-op on Windows.
+func (g *Gui) resetInputModes() error {
+	return nil
+}
@@ -4,20 +4,28 @@
 
 package gotui
 
-import "github.com/nsf/termbox-go"
+import (
+	"errors"
+
+	"github.com/nsf/termbox-go"
+)
 
 // Keybidings are used to link a given key-press event with a handler.
 type keybinding struct {
+	mode     Mode
 	viewName string
 	key      Key
 	ch       rune
 	mod      Modifier
 	handler  func(*Gui, *View) error
+
+	mouseHandler func(*Gui, *View, *MouseEvent) error
 }
 
 // newKeybinding returns a new Keybinding object.
-func newKeybinding(viewname string, key Key, ch rune, mod Modifier, handler func(*Gui, *View) error) (kb *keybinding) {
+func newKeybinding(mode Mode, viewname string, key Key, ch rune, mod Modifier, handler func(*Gui, *View) error) (kb *keybinding) {
 	kb = &keybinding{
+		mode:     mode,
 		viewName: viewname,
 		key:      key,
 		ch:       ch,
@@ -27,6 +35,17 @@ func newKeybinding(viewname string, key Key, ch rune, mod Modifier, handler func
 	return kb
 }
 
+// newMouseBinding returns a new keybinding object that handles all the mouse
+// events of a view.
+func newMouseBinding(viewname string, handler func(*Gui, *View, *MouseEvent) error) (kb *keybinding) {
+	kb = &keybinding{
+		mode:         ModeNone,
+		viewName:     viewname,
+		mouseHandler: handler,
+	}
+	return kb
+}
+
 // matchKeypress returns if the keybinding matches the keypress.
 func (kb *keybinding) matchKeypress(key Key, ch rune, mod Modifier) bool {
 	return kb.key == key && kb.ch == ch && kb.mod == mod
@@ -40,6 +59,47 @@ func (kb *keybinding) matchView(v *View) bool {
 	return v != nil && kb.viewName == v.name
 }
 
+// matchMode returns if the keybinding applies to the given mode.
+func (kb *keybinding) matchMode(mode Mode) bool {
+	return kb.mode == ModeNone || kb.mode == mode
+}
+
+// SetKeybinding creates a new keybinding owned by the view, which is
+// triggered while the view is the current one or, for mouse keys, when the
+// event happens on the view. Unlike the keybindings set
+// with Gui.SetKeybinding, it does not depend on the name of the view and it
+// is deleted with the view. View keybindings are executed before the Gui
+// ones.
+func (v *View) SetKeybinding(key interface{}, mod Modifier, handler func(*Gui, *View) error) error {
+	k, ch, err := getKey(key)
+	if err != nil {
+		return err
+	}
+	v.keybindings = append(v.keybindings, newKeybinding(ModeNone, "", k, ch, mod, handler))
+	return nil
+}
+
+// DeleteKeybinding deletes a keybinding owned by the view.
+func (v *View) DeleteKeybinding(key interface{}, mod Modifier) error {
+	k, ch, err := getKey(key)
+	if err != nil {
+		return err
+	}
+
+	for i, kb := range v.keybindings {
+		if kb.ch == ch && kb.key == k && kb.mod == mod {
+			v.keybindings = append(v.keybindings[:i], v.keybindings[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("keybinding not found")
+}
+
+// DeleteKeybindings deletes all keybindings owned by the view.
+func (v *View) DeleteKeybindings() {
+	v.keybindings = nil
+}
+
 // Key represents special keys or keys combinations.
 type Key termbox.Key
 
@@ -132,6 +192,14 @@ type Modifier termbox.Modifier
 
 // Modifiers.
 const (
-	ModNone Modifier = Modifier(0)
-	ModAlt           = Modifier(termbox.ModAlt)
+	ModNone   Modifier = Modifier(0)
+	ModAlt             = Modifier(termbox.ModAlt)
+	ModMotion          = Modifier(termbox.ModMotion)
+
+	// ModShift, ModCtrl and ModMeta are only reported for keys and mouse
+	// events that the terminal encodes with their modifiers, like
+	// Ctrl+ArrowLeft. See Gui.ExtendedKeys.
+	ModShift Modifier = 1 << 2
+	ModCtrl  Modifier = 1 << 3
+	ModMeta  Modifier = 1 << 4
 )
@@ -0,0 +1,130 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"errors"
+	"sort"
+)
+
+// Layer represents a stacking level of views. Views in higher layers are
+// drawn on top of the views in lower layers, no matter the order in which
+// they were created or raised.
+type Layer int
+
+// Predefined layers. Any other value can be used too.
+const (
+	LayerBackground Layer = -100
+	LayerNormal     Layer = 0
+	LayerPopup      Layer = 100
+	LayerDialog     Layer = 150
+	LayerTooltip    Layer = 200
+)
+
+// sortViews sorts the views by layer and z-index. Views with the same layer
+// and z-index keep their relative order.
+func (g *Gui) sortViews() {
+	sort.SliceStable(g.views, func(i, j int) bool {
+		vi, vj := g.views[i], g.views[j]
+		if vi.Layer != vj.Layer {
+			return vi.Layer < vj.Layer
+		}
+		return vi.ZIndex < vj.ZIndex
+	})
+}
+
+// errInvalidGroup is returned by the group functions when the group name is
+// empty, which would match all the views without a group.
+var errInvalidGroup = errors.New("invalid group")
+
+// GroupViews returns the views that belong to the given group, from bottom
+// to top. Views without a group do not belong to any, so it returns nil if
+// group is empty.
+func (g *Gui) GroupViews(group string) []*View {
+	if group == "" {
+		return nil
+	}
+	var views []*View
+	for _, v := range g.views {
+		if v.Group == group {
+			views = append(views, v)
+		}
+	}
+	return views
+}
+
+// SetGroupOnTop sets the views of the given group on top of the existing
+// ones, keeping their relative order. It returns error ErrUnknownView if the
+// group has no views and an error if group is empty.
+func (g *Gui) SetGroupOnTop(group string) error {
+	if group == "" {
+		return errInvalidGroup
+	}
+	var views, others []*View
+	for _, v := range g.views {
+		if v.Group == group {
+			views = append(views, v)
+		} else {
+			others = append(others, v)
+		}
+	}
+	if len(views) == 0 {
+		return ErrUnknownView
+	}
+	g.views = append(others, views...)
+	return nil
+}
+
+// MoveGroup moves the views of the given group by the given offset. It
+// returns error ErrUnknownView if the group has no views and an error if
+// group is empty.
+func (g *Gui) MoveGroup(group string, dx, dy int) error {
+	if group == "" {
+		return errInvalidGroup
+	}
+	views := g.GroupViews(group)
+	if len(views) == 0 {
+		return ErrUnknownView
+	}
+	for _, v := range views {
+		v.x0, v.y0, v.x1, v.y1 = v.x0+dx, v.y0+dy, v.x1+dx, v.y1+dy
+	}
+	return nil
+}
+
+// SetGroupVisible shows or hides the views of the given group. It returns
+// error ErrUnknownView if the group has no views and an error if group is
+// empty.
+func (g *Gui) SetGroupVisible(group string, visible bool) error {
+	if group == "" {
+		return errInvalidGroup
+	}
+	views := g.GroupViews(group)
+	if len(views) == 0 {
+		return ErrUnknownView
+	}
+	for _, v := range views {
+		v.Visible = visible
+	}
+	return nil
+}
+
+// DeleteGroup deletes the views of the given group. It returns error
+// ErrUnknownView if the group has no views and an error if group is empty.
+func (g *Gui) DeleteGroup(group string) error {
+	if group == "" {
+		return errInvalidGroup
+	}
+	views := g.GroupViews(group)
+	if len(views) == 0 {
+		return ErrUnknownView
+	}
+	for _, v := range views {
+		if err := g.DeleteView(v.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
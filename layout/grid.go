@@ -0,0 +1,236 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package layout
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/makyo/gotui"
+)
+
+// Grid is a Node that places its items in the cells of a grid, like CSS
+// grids do. Items are placed either by row and column indices or by the
+// name of an area:
+//
+//	grid := &layout.Grid{
+//		Rows:    []layout.Size{layout.Fixed(3), layout.Ratio(1), layout.Auto()},
+//		Columns: []layout.Size{layout.Ratio(1), layout.Ratio(3)},
+//		Areas: []string{
+//			"header header",
+//			"side   main",
+//			"side   footer",
+//		},
+//		Items: []*layout.GridItem{
+//			{Node: &layout.View{Name: "header"}, Area: "header"},
+//			{Node: &layout.View{Name: "side"}, Area: "side"},
+//			{Node: &layout.View{Name: "main"}, Row: 1, Column: 1},
+//			{Node: &layout.View{Name: "footer"}, Row: 2, Column: 1},
+//		},
+//	}
+//
+// Automatic tracks fit the content of the views that only span that track.
+// If the space is not enough for all the tracks, the last ones are collapsed
+// and the views placed in them are hidden.
+type Grid struct {
+	// Size is the size of the grid inside its parent.
+	Size Size
+
+	// Rows and Columns are the sizes of the tracks of the grid.
+	Rows, Columns []Size
+
+	// Gap is the number of cells between two consecutive tracks. A gap of
+	// -1 makes the frames of adjacent views overlap, so they share their
+	// borders.
+	Gap int
+
+	// Padding is the space left around the tracks.
+	Padding gotui.Padding
+
+	// Areas optionally gives names to the cells of the grid. Each string is
+	// a row, containing the names of its cells separated by spaces. Cells
+	// named "." do not belong to any area. Every area must be rectangular,
+	// otherwise Layout returns an error.
+	Areas []string
+
+	// Items are the nodes placed in the grid.
+	Items []*GridItem
+}
+
+// GridItem places a node in a Grid.
+type GridItem struct {
+	// Node is the node placed in the grid.
+	Node Node
+
+	// Area, if not empty, is the name of the area of the grid where the
+	// node is placed. Otherwise, the node is placed at the given row and
+	// column.
+	Area string
+
+	// Row and Column are the indices of the first track of the node.
+	Row, Column int
+
+	// RowSpan and ColumnSpan are the number of tracks taken by the node.
+	// A value of 0 means 1.
+	RowSpan, ColumnSpan int
+}
+
+// Constraint returns the size of the grid.
+func (gr *Grid) Constraint() Size {
+	return gr.Size
+}
+
+// Layout places the items of the grid inside the given rectangle.
+func (gr *Grid) Layout(g *gotui.Gui, x0, y0, x1, y1 int) error {
+	x0, y0 = x0+gr.Padding.Left, y0+gr.Padding.Top
+	x1, y1 = x1-gr.Padding.Right, y1-gr.Padding.Bottom
+
+	cells := make([]gridCell, len(gr.Items))
+	for i, it := range gr.Items {
+		c, err := gr.cell(it)
+		if err != nil {
+			return err
+		}
+		cells[i] = c
+	}
+
+	rows := gr.tracks(g, gr.Rows, cells, y1-y0+1, true)
+	cols := gr.tracks(g, gr.Columns, cells, x1-x0+1, false)
+	rowStart := trackStarts(y0, rows, gr.Gap)
+	colStart := trackStarts(x0, cols, gr.Gap)
+
+	for i, it := range gr.Items {
+		c := cells[i]
+		if c.row+c.rowSpan > len(rows) || c.col+c.colSpan > len(cols) {
+			return errors.New("grid item out of bounds")
+		}
+
+		ix0, iy0 := colStart[c.col], rowStart[c.row]
+		last := c.col + c.colSpan - 1
+		ix1 := colStart[last] + cols[last] - 1
+		last = c.row + c.rowSpan - 1
+		iy1 := rowStart[last] + rows[last] - 1
+		if collapsed(rows[c.row:c.row+c.rowSpan]) || collapsed(cols[c.col:c.col+c.colSpan]) {
+			// an empty rectangle hides the views of the node
+			ix1, iy1 = ix0-1, iy0-1
+		}
+
+		if err := it.Node.Layout(g, ix0, iy0, ix1, iy1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gridCell is the position of an item in the grid.
+type gridCell struct {
+	row, col         int
+	rowSpan, colSpan int
+}
+
+// cell returns the position of an item, looking up its area if needed.
+func (gr *Grid) cell(it *GridItem) (gridCell, error) {
+	if it.Area == "" {
+		c := gridCell{row: it.Row, col: it.Column, rowSpan: it.RowSpan, colSpan: it.ColumnSpan}
+		if c.rowSpan < 1 {
+			c.rowSpan = 1
+		}
+		if c.colSpan < 1 {
+			c.colSpan = 1
+		}
+		if c.row < 0 || c.col < 0 {
+			return gridCell{}, errors.New("grid item out of bounds")
+		}
+		return c, nil
+	}
+
+	r0, c0, r1, c1 := -1, -1, -1, -1
+	for r, row := range gr.Areas {
+		for c, name := range strings.Fields(row) {
+			if name != it.Area {
+				continue
+			}
+			if r0 == -1 || r < r0 {
+				r0 = r
+			}
+			if c0 == -1 || c < c0 {
+				c0 = c
+			}
+			if r > r1 {
+				r1 = r
+			}
+			if c > c1 {
+				c1 = c
+			}
+		}
+	}
+	if r0 == -1 {
+		return gridCell{}, errors.New("unknown grid area: " + it.Area)
+	}
+	for r := r0; r <= r1; r++ {
+		names := strings.Fields(gr.Areas[r])
+		for c := c0; c <= c1; c++ {
+			if c >= len(names) || names[c] != it.Area {
+				return gridCell{}, errors.New("grid area is not rectangular: " + it.Area)
+			}
+		}
+	}
+	return gridCell{row: r0, col: c0, rowSpan: r1 - r0 + 1, colSpan: c1 - c0 + 1}, nil
+}
+
+// tracks returns the number of cells of each track, resolving automatic
+// sizes from the content of the views that only span one track.
+func (gr *Grid) tracks(g *gotui.Gui, sizes []Size, cells []gridCell, length int, rows bool) []int {
+	sizes = append([]Size(nil), sizes...)
+	for i, s := range sizes {
+		if s.kind != sizeAuto {
+			continue
+		}
+
+		content := -1
+		for j, c := range cells {
+			start, span := c.col, c.colSpan
+			if rows {
+				start, span = c.row, c.rowSpan
+			}
+			if start != i || span != 1 {
+				continue
+			}
+			w, h := contentSize(g, gr.Items[j].Node)
+			if rows {
+				w = h
+			}
+			if w > content {
+				content = w
+			}
+		}
+		sizes[i] = s.resolve(content)
+	}
+
+	if len(sizes) > 1 {
+		length -= gr.Gap * (len(sizes) - 1)
+	}
+	return fit(length, sizes)
+}
+
+// trackStarts returns the first cell of each track.
+func trackStarts(pos int, tracks []int, gap int) []int {
+	starts := make([]int, len(tracks))
+	for i, n := range tracks {
+		starts[i] = pos
+		pos += n + gap
+	}
+	return starts
+}
+
+// collapsed returns if any of the given tracks has been collapsed.
+func collapsed(tracks []int) bool {
+	for _, n := range tracks {
+		if n <= 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package layout
+
+import "testing"
+
+func TestGridAreas(t *testing.T) {
+	areas := []string{
+		"header header header",
+		"side   main   main",
+		"side   main   main",
+		"l      l      .",
+		"l      .      .",
+		"d      .      d",
+	}
+	tests := []struct {
+		area string
+		want gridCell
+		ok   bool
+	}{
+		{"header", gridCell{row: 0, col: 0, rowSpan: 1, colSpan: 3}, true},
+		{"side", gridCell{row: 1, col: 0, rowSpan: 2, colSpan: 1}, true},
+		{"main", gridCell{row: 1, col: 1, rowSpan: 2, colSpan: 2}, true},
+		{"l", gridCell{}, false},
+		{"d", gridCell{}, false},
+		{"missing", gridCell{}, false},
+	}
+	gr := &Grid{Areas: areas}
+	for _, tt := range tests {
+		c, err := gr.cell(&GridItem{Area: tt.area})
+		if (err == nil) != tt.ok {
+			t.Errorf("area %q: got error %v, want ok %v", tt.area, err, tt.ok)
+			continue
+		}
+		if tt.ok && c != tt.want {
+			t.Errorf("area %q: got %+v, want %+v", tt.area, c, tt.want)
+		}
+	}
+}
+
+func TestGridAreasShortRow(t *testing.T) {
+	gr := &Grid{Areas: []string{"a a", "a"}}
+	if _, err := gr.cell(&GridItem{Area: "a"}); err == nil {
+		t.Error("got no error for an area missing a cell")
+	}
+}
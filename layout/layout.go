@@ -0,0 +1,437 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+/*
+Package layout computes the position of gotui views from a declarative
+description of the layout, so there is no need to compute coordinates by
+hand in the Layout function of a manager.
+
+A layout is a tree of nodes. Boxes lay out their children in a row or a
+column, giving each one the space described by its Size:
+
+	root := layout.Column(
+		layout.Row(
+			&layout.View{Name: "side", Size: layout.Percent(25).Min(20)},
+			&layout.View{Name: "main"},
+		),
+		&layout.View{Name: "status", Size: layout.Fixed(3)},
+	)
+	g.SetManager(layout.NewManager(root))
+
+Grids place their items in the cells of a grid, by row and column indices or
+by the name of an area, and can be nested in boxes and vice versa. See Grid.
+
+SplitPanes divide their space between two children separated by a divider
+that can be dragged with the mouse or moved with the keyboard. See
+SplitPane.
+
+Widgets, like gotui.List, are placed with Widget nodes:
+
+	&layout.Widget{Widget: list, Size: layout.Percent(30)}
+
+The positions are recomputed every time the GUI is redrawn, so the layout
+adapts automatically when the terminal is resized. The views that do not
+fit in the terminal are hidden until there is enough space for them.
+*/
+package layout
+
+import (
+	"math"
+
+	"github.com/makyo/gotui"
+)
+
+// Node is an element of a layout tree.
+type Node interface {
+	// Constraint returns the size of the node along the direction of its
+	// parent.
+	Constraint() Size
+
+	// Layout places the node in the rectangle with its top-left corner at
+	// (x0, y0) and the bottom-right one at (x1, y1), both included.
+	Layout(g *gotui.Gui, x0, y0, x1, y1 int) error
+}
+
+// Manager is a gotui.Manager that lays out a tree of nodes using the whole
+// terminal.
+type Manager struct {
+	// Root is the root node of the layout tree.
+	Root Node
+}
+
+// NewManager returns a new Manager with the given root node.
+func NewManager(root Node) *Manager {
+	return &Manager{Root: root}
+}
+
+// Layout lays out the root node using the whole terminal.
+func (m *Manager) Layout(g *gotui.Gui) error {
+	maxX, maxY := g.Size()
+	return m.Root.Layout(g, 0, 0, maxX-1, maxY-1)
+}
+
+// Direction is the direction in which a Box lays out its children.
+type Direction int
+
+// Directions.
+const (
+	Horizontal Direction = iota
+	Vertical
+)
+
+// Box is a Node that lays out its children one after another, in a row or
+// in a column. Every child takes the whole space of the box in the other
+// direction.
+type Box struct {
+	// Direction is the direction in which the children are laid out.
+	Direction Direction
+
+	// Size is the size of the box inside its parent.
+	Size Size
+
+	// Gap is the number of cells between two consecutive children. A gap
+	// of -1 makes the frames of adjacent views overlap, so they share
+	// their borders.
+	Gap int
+
+	// Padding is the space left around the children.
+	Padding gotui.Padding
+
+	// Children are the nodes laid out by the box.
+	Children []Node
+}
+
+// Row returns a Box that lays out the given children horizontally.
+func Row(children ...Node) *Box {
+	return &Box{Direction: Horizontal, Children: children}
+}
+
+// Column returns a Box that lays out the given children vertically.
+func Column(children ...Node) *Box {
+	return &Box{Direction: Vertical, Children: children}
+}
+
+// Constraint returns the size of the box.
+func (b *Box) Constraint() Size {
+	return b.Size
+}
+
+// Layout lays out the children of the box inside the given rectangle.
+func (b *Box) Layout(g *gotui.Gui, x0, y0, x1, y1 int) error {
+	if len(b.Children) == 0 {
+		return nil
+	}
+
+	x0, y0 = x0+b.Padding.Left, y0+b.Padding.Top
+	x1, y1 = x1-b.Padding.Right, y1-b.Padding.Bottom
+
+	length := x1 - x0 + 1
+	if b.Direction == Vertical {
+		length = y1 - y0 + 1
+	}
+	length -= b.Gap * (len(b.Children) - 1)
+
+	sizes := make([]Size, len(b.Children))
+	for i, c := range b.Children {
+		sizes[i] = c.Constraint()
+		if sizes[i].kind == sizeAuto {
+			w, h := contentSize(g, c)
+			if b.Direction == Vertical {
+				sizes[i] = sizes[i].resolve(h)
+			} else {
+				sizes[i] = sizes[i].resolve(w)
+			}
+		}
+	}
+
+	pos := x0
+	if b.Direction == Vertical {
+		pos = y0
+	}
+	for i, n := range fit(length, sizes) {
+		var err error
+		if b.Direction == Vertical {
+			err = b.Children[i].Layout(g, x0, pos, x1, pos+n-1)
+		} else {
+			err = b.Children[i].Layout(g, pos, y0, pos+n-1, y1)
+		}
+		if err != nil {
+			return err
+		}
+		pos += n + b.Gap
+	}
+	return nil
+}
+
+// View is a Node that places a gotui view.
+type View struct {
+	// Name is the name of the view.
+	Name string
+
+	// Size is the size of the view inside its parent.
+	Size Size
+
+	// Init, if not nil, is called when the view is created. It can be
+	// used to configure the view and write its initial content.
+	Init func(v *gotui.View) error
+
+	hidden bool // the view has been hidden because it does not fit
+}
+
+// Constraint returns the size of the view.
+func (n *View) Constraint() Size {
+	return n.Size
+}
+
+// Layout places the view in the given rectangle. If the view has a frame,
+// it is drawn on the edges of the rectangle. Otherwise, the content of the
+// view takes the whole rectangle. If the rectangle is too small, the view
+// is hidden until it fits again.
+func (n *View) Layout(g *gotui.Gui, x0, y0, x1, y1 int) error {
+	frame := true
+	if v, err := g.View(n.Name); err == nil {
+		frame = v.Frame
+	}
+	fits := !((frame && (x1 <= x0 || y1 <= y0)) || x1 < x0 || y1 < y0)
+
+	v, err := setView(g, n.Name, x0, y0, x1, y1, frame)
+	if err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		if n.Init != nil {
+			if err := n.Init(v); err != nil {
+				return err
+			}
+		}
+		if !v.Frame {
+			if _, err := setView(g, n.Name, x0, y0, x1, y1, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	// only show the views hidden by the layout, so the views hidden by
+	// the application stay hidden
+	switch {
+	case !fits:
+		v.Visible = false
+		n.hidden = true
+	case n.hidden:
+		v.Visible = true
+		n.hidden = false
+	}
+	return nil
+}
+
+// setView calls g.SetView, making sure that the dimensions of the view are
+// valid even if the rectangle is too small.
+func setView(g *gotui.Gui, name string, x0, y0, x1, y1 int, frame bool) (*gotui.View, error) {
+	if !frame {
+		x0, y0, x1, y1 = x0-1, y0-1, x1+1, y1+1
+	}
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+	return g.SetView(name, x0, y0, x1, y1)
+}
+
+// sizeKind represents the type of a Size.
+type sizeKind int
+
+const (
+	sizeRatio sizeKind = iota
+	sizeFixed
+	sizePercent
+	sizeAuto
+)
+
+// Size describes how much space a node takes inside its parent. The zero
+// Size takes an equal share of the space left by the other nodes, like
+// Ratio(1).
+type Size struct {
+	kind     sizeKind
+	value    float64
+	min, max int
+}
+
+// Fixed returns a Size of the given number of cells.
+func Fixed(cells int) Size {
+	return Size{kind: sizeFixed, value: float64(cells)}
+}
+
+// Percent returns a Size that is the given percentage of the parent's
+// space.
+func Percent(p float64) Size {
+	return Size{kind: sizePercent, value: p}
+}
+
+// Ratio returns a Size that shares the space left by the fixed and
+// percentage sizes with the other ratios, proportionally to weight.
+func Ratio(weight float64) Size {
+	return Size{kind: sizeRatio, value: weight}
+}
+
+// Auto returns a Size that fits the content of a view, including its frame.
+// Nodes other than views take it as Ratio(1).
+func Auto() Size {
+	return Size{kind: sizeAuto}
+}
+
+// resolve returns the Size used for an automatic size, given the size of
+// the content.
+func (s Size) resolve(content int) Size {
+	if content < 0 {
+		return Ratio(1).Min(s.min).Max(s.max)
+	}
+	return Fixed(content).Min(s.min).Max(s.max)
+}
+
+// contentSize returns the size of the content of a View node, including its
+// frame, or -1 if the node is not a view or the view does not exist yet.
+func contentSize(g *gotui.Gui, n Node) (w, h int) {
+	vn, ok := n.(*View)
+	if !ok {
+		return -1, -1
+	}
+	v, err := g.View(vn.Name)
+	if err != nil {
+		return -1, -1
+	}
+
+	lines := v.BufferLines()
+	for _, l := range lines {
+		if n := gotui.TextWidth(l); n > w {
+			w = n
+		}
+	}
+	h = len(lines)
+	w += v.Padding.Left + v.Padding.Right
+	h += v.Padding.Top + v.Padding.Bottom
+	if v.Frame {
+		w, h = w+2, h+2
+	}
+	return w, h
+}
+
+// Min returns a copy of s that takes at least the given number of cells.
+func (s Size) Min(cells int) Size {
+	s.min = cells
+	return s
+}
+
+// Max returns a copy of s that takes at most the given number of cells.
+func (s Size) Max(cells int) Size {
+	s.max = cells
+	return s
+}
+
+// clamp limits n to the minimum and maximum of s.
+func (s Size) clamp(n int) int {
+	if s.max > 0 && n > s.max {
+		n = s.max
+	}
+	if n < s.min {
+		n = s.min
+	}
+	if n < 0 {
+		n = 0
+	}
+	return n
+}
+
+// weight returns the weight of a ratio.
+func (s Size) weight() float64 {
+	if s.value <= 0 {
+		return 1
+	}
+	return s.value
+}
+
+// fit splits length cells among the given sizes. If the space is not enough
+// for all of them, the last ones are collapsed to 0 cells.
+func fit(length int, sizes []Size) []int {
+	sizes = append([]Size(nil), sizes...)
+	for n := len(sizes) - 1; ; n-- {
+		cells := distribute(length, sizes)
+		total := 0
+		for _, c := range cells {
+			total += c
+		}
+		if total <= length || n < 0 {
+			return cells
+		}
+		sizes[n] = Fixed(0)
+	}
+}
+
+// distribute splits length cells among the given sizes.
+func distribute(length int, sizes []Size) []int {
+	cells := make([]int, len(sizes))
+	done := make([]bool, len(sizes))
+
+	remaining := length
+	for i, s := range sizes {
+		switch s.kind {
+		case sizeFixed:
+			cells[i] = s.clamp(int(s.value))
+		case sizePercent:
+			cells[i] = s.clamp(int(float64(length) * s.value / 100))
+		default:
+			continue
+		}
+		done[i] = true
+		remaining -= cells[i]
+	}
+
+	// Ratios are distributed using cumulative rounding, so the sum is
+	// exact. The ones that violate their limits are fixed to them and the
+	// rest is distributed again.
+	for {
+		var total float64
+		for i, s := range sizes {
+			if !done[i] {
+				total += s.weight()
+			}
+		}
+		if total == 0 {
+			break
+		}
+
+		avail := remaining
+		if avail < 0 {
+			avail = 0
+		}
+		var (
+			acc      float64
+			prev     int
+			violated bool
+		)
+		for i, s := range sizes {
+			if done[i] {
+				continue
+			}
+			acc += s.weight()
+			end := int(math.Round(acc / total * float64(avail)))
+			cells[i], prev = end-prev, end
+			if c := s.clamp(cells[i]); c != cells[i] {
+				violated = true
+			}
+		}
+		if !violated {
+			break
+		}
+		for i, s := range sizes {
+			if c := s.clamp(cells[i]); !done[i] && c != cells[i] {
+				cells[i] = c
+				done[i] = true
+				remaining -= c
+			}
+		}
+	}
+	return cells
+}
@@ -0,0 +1,129 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package layout
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/makyo/gotui"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		sizes  []Size
+		want   []int
+	}{
+		{"equal ratios", 10, []Size{Ratio(1), Ratio(1), Ratio(1)}, []int{3, 4, 3}},
+		{"weighted ratios", 7, []Size{Ratio(2), Ratio(1)}, []int{5, 2}},
+		{"zero size", 9, []Size{{}, Ratio(2)}, []int{3, 6}},
+		{"fixed and ratio", 10, []Size{Fixed(3), Ratio(1)}, []int{3, 7}},
+		{"percent and ratios", 100, []Size{Percent(25), Ratio(1), Ratio(3)}, []int{25, 19, 56}},
+		{"percent rounds down", 10, []Size{Percent(33), Ratio(1)}, []int{3, 7}},
+		{"fixed under length", 10, []Size{Fixed(2), Fixed(3)}, []int{2, 3}},
+		{"fixed with min", 10, []Size{Fixed(2).Min(4), Ratio(1)}, []int{4, 6}},
+		{"fixed with max", 10, []Size{Fixed(8).Max(5), Ratio(1)}, []int{5, 5}},
+		{"percent with max", 100, []Size{Percent(50).Max(10), Ratio(1)}, []int{10, 90}},
+		{"ratio with max", 10, []Size{Ratio(1).Max(2), Ratio(1)}, []int{2, 8}},
+		{"ratio with min", 10, []Size{Ratio(1).Min(8), Ratio(1), Ratio(1)}, []int{8, 1, 1}},
+		{"all ratios at max", 10, []Size{Ratio(1).Max(2), Ratio(1).Max(3)}, []int{2, 3}},
+		{"no space", 0, []Size{Ratio(1), Ratio(1)}, []int{0, 0}},
+		{"negative space", -3, []Size{Ratio(1), Fixed(2)}, []int{0, 0}},
+		{"fixed over length", 10, []Size{Fixed(6), Fixed(6)}, []int{6, 0}},
+		{"fixed over length with ratio", 10, []Size{Fixed(4), Ratio(1), Fixed(8)}, []int{4, 6, 0}},
+		{"all collapsed", 3, []Size{Fixed(4), Fixed(5)}, []int{0, 0}},
+		{"mins over length", 3, []Size{Ratio(1).Min(2), Ratio(1).Min(2)}, []int{3, 0}},
+		{"percents over length", 10, []Size{Percent(60), Percent(60)}, []int{6, 0}},
+		{"empty", 10, nil, []int{}},
+	}
+	for _, tt := range tests {
+		got := fit(tt.length, tt.sizes)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: fit(%d, %v) = %v, want %v", tt.name, tt.length, tt.sizes, got, tt.want)
+		}
+	}
+}
+
+func TestFitProperties(t *testing.T) {
+	sets := [][]Size{
+		{Ratio(1), Ratio(1), Ratio(1)},
+		{Ratio(1), Ratio(2), Ratio(3), Ratio(5)},
+		{Fixed(3), Ratio(1), Fixed(2)},
+		{Percent(30), Ratio(1), Percent(20).Min(3)},
+		{Percent(25).Min(5).Max(8), Ratio(2), Ratio(1).Max(4)},
+		{Ratio(1).Min(4), Ratio(1).Max(3), Ratio(1)},
+		{Fixed(5), Fixed(7), Ratio(1).Min(2), Ratio(1)},
+		{Ratio(1).Min(3).Max(6), Ratio(3).Min(1).Max(10), Ratio(1)},
+		{Fixed(10), Percent(50), Ratio(1), Ratio(1).Min(1)},
+	}
+	for _, sizes := range sets {
+		for length := 0; length <= 60; length++ {
+			cells := fit(length, sizes)
+			if len(cells) != len(sizes) {
+				t.Fatalf("fit(%d, %v) = %v: wrong number of cells", length, sizes, cells)
+			}
+
+			total, required, plain := 0, 0, false
+			for i, s := range sizes {
+				c := cells[i]
+				total += c
+				// collapsed sizes take 0 cells, the rest respect their
+				// limits
+				if c < 0 || c != 0 && c != s.clamp(c) {
+					t.Errorf("fit(%d, %v) = %v: cell %d out of limits", length, sizes, cells, i)
+				}
+				switch s.kind {
+				case sizeFixed:
+					required += s.clamp(int(s.value))
+				case sizePercent:
+					required += s.clamp(int(float64(length) * s.value / 100))
+				default:
+					required += s.min
+					if s.min == 0 && s.max == 0 {
+						plain = true
+					}
+				}
+			}
+
+			if total > length && total > 0 {
+				t.Errorf("fit(%d, %v) = %v: %d cells do not fit", length, sizes, cells, total)
+			}
+			// a ratio without limits takes the space left by the rest
+			if plain && required <= length && total != length {
+				t.Errorf("fit(%d, %v) = %v: %d cells, want %d", length, sizes, cells, total, length)
+			}
+		}
+	}
+}
+
+func TestContentSize(t *testing.T) {
+	tests := []struct {
+		text  string
+		frame bool
+		w, h  int
+	}{
+		{"hello", true, 7, 3},
+		{"hello", false, 5, 1},
+		{"日本語", true, 8, 3},
+		{"ab\n日本語テキスト\nc", false, 14, 3},
+	}
+	for _, tt := range tests {
+		g := &gotui.Gui{}
+		v, err := g.SetView("v", 0, 0, 40, 10)
+		if err != gotui.ErrUnknownView {
+			t.Fatal(err)
+		}
+		v.Frame = tt.frame
+		fmt.Fprint(v, tt.text)
+
+		w, h := contentSize(g, &View{Name: "v"})
+		if w != tt.w || h != tt.h {
+			t.Errorf("content %q: got %dx%d, want %dx%d", tt.text, w, h, tt.w, tt.h)
+		}
+	}
+}
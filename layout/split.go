@@ -0,0 +1,271 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package layout
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/makyo/gotui"
+)
+
+// SplitPane is a Node that divides its space between two children separated
+// by a divider. The divider can be dragged with the mouse, if enabled in the
+// GUI, and moved with the keyboard. The children share the divider as the
+// edge of their frames. SplitPanes can be nested to build more complex
+// layouts.
+type SplitPane struct {
+	// Name is the name of the view used to draw the divider. It must be
+	// unique.
+	Name string
+
+	// Direction is the direction in which the children are laid out. With
+	// Horizontal, the divider is vertical.
+	Direction Direction
+
+	// Size is the size of the split pane inside its parent.
+	Size Size
+
+	// First and Second are the children of the split pane.
+	First, Second Node
+
+	// Ratio is the portion of the space taken by the first child. It is
+	// updated when the divider is moved, so it can be persisted and
+	// restored later. A value of 0 means 0.5.
+	Ratio float64
+
+	// MinFirst and MinSecond are the minimum number of cells taken by the
+	// first and second child respectively.
+	MinFirst, MinSecond int
+
+	// If ShrinkKey and GrowKey are not nil, they move the divider one cell
+	// towards the first and the second child respectively, while any of
+	// the views of the split pane is the current view. They are combined
+	// with KeyMod.
+	ShrinkKey, GrowKey interface{}
+	KeyMod             gotui.Modifier
+
+	// OnChange, if not nil, is called every time the divider is moved.
+	OnChange func(g *gotui.Gui, s *SplitPane) error
+
+	start, length int // position of the split pane in the last layout
+	dragging      bool
+	drawn         dividerState // state of the divider when it was drawn
+}
+
+// dividerState holds what is drawn in the divider view, so it is only
+// redrawn when it changes.
+type dividerState struct {
+	direction Direction
+	ch        string
+	n         int
+	dragging  bool
+}
+
+// Constraint returns the size of the split pane.
+func (s *SplitPane) Constraint() Size {
+	return s.Size
+}
+
+// Layout lays out the children of the split pane and its divider inside the
+// given rectangle.
+func (s *SplitPane) Layout(g *gotui.Gui, x0, y0, x1, y1 int) error {
+	if s.Direction == Vertical {
+		s.start, s.length = y0, y1-y0+1
+	} else {
+		s.start, s.length = x0, x1-x0+1
+	}
+	d := s.clamp(int(math.Round(s.ratio() * float64(s.length-1))))
+
+	var err error
+	if s.Direction == Vertical {
+		if err = s.First.Layout(g, x0, y0, x1, y0+d); err == nil {
+			err = s.Second.Layout(g, x0, y0+d, x1, y1)
+		}
+	} else {
+		if err = s.First.Layout(g, x0, y0, x0+d, y1); err == nil {
+			err = s.Second.Layout(g, x0+d, y0, x1, y1)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return s.layoutDivider(g, x0, y0, x1, y1, s.start+d)
+}
+
+// layoutDivider places the divider view at the given position, over the
+// shared edge of the children's frames.
+func (s *SplitPane) layoutDivider(g *gotui.Gui, x0, y0, x1, y1, pos int) error {
+	var (
+		dx0, dy0, dx1, dy1 int
+		ch                 string
+		n                  int
+	)
+	if s.Direction == Vertical {
+		dx0, dy0, dx1, dy1 = x0, pos-1, x1, pos+1
+		ch, n = "─", x1-x0-1
+		if g.ASCII {
+			ch = "-"
+		}
+	} else {
+		dx0, dy0, dx1, dy1 = pos-1, y0, pos+1, y1
+		ch, n = "│", y1-y0-1
+		if g.ASCII {
+			ch = "|"
+		}
+	}
+
+	v, err := g.SetView(s.Name, dx0, dy0, dx1, dy1)
+	if err != nil {
+		if err != gotui.ErrUnknownView {
+			return err
+		}
+		v.Frame = false
+		if err := s.bind(g); err != nil {
+			return err
+		}
+		s.drawn = dividerState{}
+	}
+
+	v.FgColor = g.FgColor
+	if s.dragging {
+		v.FgColor = g.SelFgColor
+	}
+	state := dividerState{direction: s.Direction, ch: ch, n: n, dragging: s.dragging}
+	if state == s.drawn {
+		return nil
+	}
+	s.drawn = state
+	v.Clear()
+	if n > 0 {
+		if s.Direction == Vertical {
+			fmt.Fprint(v, strings.Repeat(ch, n))
+		} else {
+			fmt.Fprint(v, strings.TrimSuffix(strings.Repeat(ch+"\n", n), "\n"))
+		}
+	}
+	return nil
+}
+
+// bind sets the mouse binding of the divider and the keybindings of the
+// split pane.
+func (s *SplitPane) bind(g *gotui.Gui) error {
+	if err := g.SetMouseBinding(s.Name, s.onMouse); err != nil {
+		return err
+	}
+
+	keys := []struct {
+		key   interface{}
+		delta int
+	}{{s.ShrinkKey, -1}, {s.GrowKey, 1}}
+	for _, k := range keys {
+		if k.key == nil {
+			continue
+		}
+		for _, name := range viewNames(s) {
+			if err := g.SetKeybinding(name, k.key, s.KeyMod, s.moveHandler(k.delta)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// onMouse drags the divider.
+func (s *SplitPane) onMouse(g *gotui.Gui, v *gotui.View, ev *gotui.MouseEvent) error {
+	switch {
+	case ev.Action == gotui.MouseActionPress && ev.Button == gotui.MouseLeft:
+		s.dragging = true
+		return g.CaptureMouse(s.Name)
+	case ev.Action == gotui.MouseActionDrag && s.dragging:
+		pos := ev.X
+		if s.Direction == Vertical {
+			pos = ev.Y
+		}
+		return s.moveTo(g, pos-s.start)
+	case ev.Action == gotui.MouseActionRelease:
+		s.dragging = false
+	}
+	return nil
+}
+
+// moveHandler returns a keybinding handler that moves the divider by delta
+// cells.
+func (s *SplitPane) moveHandler(delta int) func(*gotui.Gui, *gotui.View) error {
+	return func(g *gotui.Gui, v *gotui.View) error {
+		return s.Move(g, delta)
+	}
+}
+
+// Move moves the divider by delta cells.
+func (s *SplitPane) Move(g *gotui.Gui, delta int) error {
+	d := s.clamp(int(math.Round(s.ratio() * float64(s.length-1))))
+	return s.moveTo(g, d+delta)
+}
+
+// moveTo moves the divider to the given offset from the start of the split
+// pane.
+func (s *SplitPane) moveTo(g *gotui.Gui, d int) error {
+	if s.length < 2 {
+		return nil
+	}
+	s.Ratio = float64(s.clamp(d)) / float64(s.length-1)
+	if s.OnChange != nil {
+		return s.OnChange(g, s)
+	}
+	return nil
+}
+
+// ratio returns the ratio of the split pane, applying its default value.
+func (s *SplitPane) ratio() float64 {
+	if s.Ratio <= 0 {
+		return 0.5
+	}
+	return s.Ratio
+}
+
+// clamp limits the offset of the divider, so the children have at least
+// their minimum size.
+func (s *SplitPane) clamp(d int) int {
+	if max := s.length - s.MinSecond; d > max {
+		d = max
+	}
+	if max := s.length - 2; d > max {
+		d = max
+	}
+	if d < s.MinFirst-1 {
+		d = s.MinFirst - 1
+	}
+	if d < 1 {
+		d = 1
+	}
+	return d
+}
+
+// viewNames returns the names of the views in the tree of the given node.
+func viewNames(n Node) []string {
+	switch n := n.(type) {
+	case *View:
+		return []string{n.Name}
+	case *Box:
+		var names []string
+		for _, c := range n.Children {
+			names = append(names, viewNames(c)...)
+		}
+		return names
+	case *Grid:
+		var names []string
+		for _, it := range n.Items {
+			names = append(names, viewNames(it.Node)...)
+		}
+		return names
+	case *SplitPane:
+		return append(viewNames(n.First), viewNames(n.Second)...)
+	case *Widget:
+		return []string{n.Widget.Name()}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+
+	"github.com/makyo/gotui"
+)
+
+// Widget is a Node that places a gotui widget, like a List. The widget is
+// drawn with a frame on the edges of its rectangle.
+type Widget struct {
+	// Widget is the widget placed by the node.
+	Widget gotui.Widget
+
+	// Size is the size of the widget inside its parent.
+	Size Size
+
+	hidden []*gotui.View // views hidden because the widget does not fit
+}
+
+// Constraint returns the size of the widget.
+func (n *Widget) Constraint() Size {
+	return n.Size
+}
+
+// Layout places the widget in the given rectangle and lays it out. If the
+// rectangle is too small, the views of the widget are hidden until it fits
+// again. They are its main view and the views whose name starts with the
+// name of the widget followed by a dot, like the fields of a gotui.Form.
+func (n *Widget) Layout(g *gotui.Gui, x0, y0, x1, y1 int) error {
+	if x1 <= x0 || y1 <= y0 {
+		// only hide the views shown, so the views hidden by the
+		// application stay hidden
+		for _, v := range n.views(g) {
+			if v.Visible {
+				v.Visible = false
+				n.hidden = append(n.hidden, v)
+			}
+		}
+		return nil
+	}
+
+	for _, v := range n.hidden {
+		v.Visible = true
+	}
+	n.hidden = nil
+	n.Widget.SetPosition(x0, y0, x1, y1)
+	return n.Widget.Layout(g)
+}
+
+// views returns the existing views of the widget.
+func (n *Widget) views(g *gotui.Gui) []*gotui.View {
+	name := n.Widget.Name()
+	var views []*gotui.View
+	for _, v := range g.Views() {
+		if v.Name() == name || strings.HasPrefix(v.Name(), name+".") {
+			views = append(views, v)
+		}
+	}
+	return views
+}
@@ -0,0 +1,75 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"github.com/makyo/gotui"
+)
+
+// testWidget is a widget with a main view and a child view.
+type testWidget struct {
+	name           string
+	x0, y0, x1, y1 int
+}
+
+func (w *testWidget) Name() string { return w.name }
+
+func (w *testWidget) SetPosition(x0, y0, x1, y1 int) {
+	w.x0, w.y0, w.x1, w.y1 = x0, y0, x1, y1
+}
+
+func (w *testWidget) Layout(g *gotui.Gui) error {
+	if _, err := g.SetView(w.name, w.x0, w.y0, w.x1, w.y1); err != nil && err != gotui.ErrUnknownView {
+		return err
+	}
+	if _, err := g.SetView(w.name+".child", w.x0+1, w.y0+1, w.x1-1, w.y1-1); err != nil && err != gotui.ErrUnknownView {
+		return err
+	}
+	return nil
+}
+
+func TestWidgetHidden(t *testing.T) {
+	g := &gotui.Gui{}
+	n := &Widget{Widget: &testWidget{name: "w"}}
+	other, _ := g.SetView("wother", 0, 0, 5, 5)
+
+	visible := func() (bool, bool) {
+		v, err := g.View("w")
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := g.View("w.child")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v.Visible, c.Visible
+	}
+
+	if err := n.Layout(g, 0, 0, 10, 10); err != nil {
+		t.Fatal(err)
+	}
+	if v, c := visible(); !v || !c {
+		t.Fatalf("fits: got visible %v %v, want true true", v, c)
+	}
+
+	if err := n.Layout(g, 0, 0, 10, 0); err != nil {
+		t.Fatal(err)
+	}
+	if v, c := visible(); v || c {
+		t.Errorf("does not fit: got visible %v %v, want false false", v, c)
+	}
+	if !other.Visible {
+		t.Error("a view of another widget has been hidden")
+	}
+
+	if err := n.Layout(g, 0, 0, 10, 10); err != nil {
+		t.Fatal(err)
+	}
+	if v, c := visible(); !v || !c {
+		t.Errorf("fits again: got visible %v %v, want true true", v, c)
+	}
+}
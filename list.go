@@ -0,0 +1,420 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// List is a widget that shows a list of items in a view. The item under the
+// cursor is highlighted and can be moved with the arrow keys, PgUp, PgDn,
+// Home, End, the mouse wheel and clicks. Enter or a double click selects
+// it. Only the visible items are rendered, so lists can hold a large number
+// of items.
+type List struct {
+	widget
+
+	// Title is the title of the view of the list.
+	Title string
+
+	// If Multi is true, several items can be selected. The space key and
+	// clicks on the check box toggle the item under the cursor.
+	Multi bool
+
+	// If Filterable is true, typing shows only the items that contain the
+	// typed text, ignoring case. Backspace deletes the last typed rune and
+	// Esc clears the filter. The filter is shown on the footer of the view.
+	// If Multi is also true, the space key toggles items instead of being
+	// added to the filter.
+	Filterable bool
+
+	// Render, if not nil, returns the text shown for the item with the
+	// given index. Otherwise, the item itself is shown. The text can
+	// contain escape sequences to set its colors.
+	Render func(index int) string
+
+	// OnSelect, if not nil, is called when the item under the cursor is
+	// selected with Enter or a double click.
+	OnSelect func(g *Gui, l *List, index int) error
+
+	// OnChange, if not nil, is called when the item under the cursor or
+	// the set of selected items change.
+	OnChange func(g *Gui, l *List) error
+
+	items    []string
+	matches  []int // indices of the items matching the filter, nil if all
+	filter   string
+	cursor   int // position of the cursor in the shown items
+	offset   int // first shown item in the view
+	selected map[int]bool
+
+	lastCursor int  // item under the cursor in the last layout
+	selChanged bool // marks if the selection changed since the last layout
+}
+
+// NewList returns a new List with the given name and position.
+func NewList(name string, x0, y0, x1, y1 int, items []string) *List {
+	l := &List{widget: widget{name: name, x0: x0, y0: y0, x1: x1, y1: y1}}
+	l.SetItems(items)
+	return l
+}
+
+// SetItems replaces the items of the list. The filter, the cursor and the
+// selection are reset.
+func (l *List) SetItems(items []string) {
+	l.items = items
+	l.matches = nil
+	l.filter = ""
+	l.cursor, l.offset = 0, 0
+	l.selected = make(map[int]bool)
+	l.selChanged = true
+}
+
+// Items returns the items of the list.
+func (l *List) Items() []string {
+	return l.items
+}
+
+// Len returns the number of items shown, which match the filter.
+func (l *List) Len() int {
+	if l.matches == nil {
+		return len(l.items)
+	}
+	return len(l.matches)
+}
+
+// Cursor returns the index of the item under the cursor, or -1 if no item
+// is shown.
+func (l *List) Cursor() int {
+	return l.item(l.cursor)
+}
+
+// SetCursor moves the cursor to the item with the given index. It returns
+// an error if the item is not shown.
+func (l *List) SetCursor(index int) error {
+	for i := 0; i < l.Len(); i++ {
+		if l.item(i) == index {
+			l.cursor = i
+			return nil
+		}
+	}
+	return errors.New("invalid index")
+}
+
+// Selected returns the indices of the selected items, in ascending order.
+// If Multi is false, the selected item is the one under the cursor.
+func (l *List) Selected() []int {
+	if !l.Multi {
+		if i := l.Cursor(); i >= 0 {
+			return []int{i}
+		}
+		return nil
+	}
+	s := make([]int, 0, len(l.selected))
+	for i := range l.selected {
+		s = append(s, i)
+	}
+	sort.Ints(s)
+	return s
+}
+
+// IsSelected returns if the item with the given index is selected.
+func (l *List) IsSelected(index int) bool {
+	if !l.Multi {
+		return index == l.Cursor()
+	}
+	return l.selected[index]
+}
+
+// SetSelected selects or unselects the item with the given index. It is
+// only meaningful if Multi is true.
+func (l *List) SetSelected(index int, selected bool) error {
+	if index < 0 || index >= len(l.items) {
+		return errors.New("invalid index")
+	}
+	if selected {
+		l.selected[index] = true
+	} else {
+		delete(l.selected, index)
+	}
+	l.selChanged = true
+	return nil
+}
+
+// Filter returns the current filter of the list.
+func (l *List) Filter() string {
+	return l.filter
+}
+
+// SetFilter shows only the items that contain s, ignoring case. If the new
+// filter extends the previous one, only the items matching the previous
+// filter are checked. The cursor stays on the same item if it is still
+// shown.
+func (l *List) SetFilter(s string) {
+	cur := l.Cursor()
+	if s == "" {
+		l.matches = nil
+	} else {
+		candidates := l.matches
+		if candidates == nil || !strings.HasPrefix(s, l.filter) {
+			candidates = make([]int, len(l.items))
+			for i := range candidates {
+				candidates[i] = i
+			}
+		}
+		ls := strings.ToLower(s)
+		matches := make([]int, 0, len(candidates))
+		for _, i := range candidates {
+			if strings.Contains(strings.ToLower(l.items[i]), ls) {
+				matches = append(matches, i)
+			}
+		}
+		l.matches = matches
+	}
+	l.filter = s
+
+	l.cursor, l.offset = 0, 0
+	if cur >= 0 {
+		l.SetCursor(cur)
+	}
+}
+
+// Layout draws the list, creating its view if needed.
+func (l *List) Layout(g *Gui) error {
+	v, err := g.SetView(l.name, l.x0, l.y0, l.x1, l.y1)
+	if err != nil {
+		if err != ErrUnknownView {
+			return err
+		}
+		if err := l.bind(v); err != nil {
+			return err
+		}
+	}
+	v.Title = l.Title
+	v.Editable = l.Filterable
+	if l.Filterable {
+		v.Footer = ""
+		if l.filter != "" {
+			v.Footer = "/" + l.filter
+		}
+	}
+	l.draw(v)
+
+	cur := l.Cursor()
+	if cur != l.lastCursor || l.selChanged {
+		l.lastCursor, l.selChanged = cur, false
+		if l.OnChange != nil {
+			return l.OnChange(g, l)
+		}
+	}
+	return nil
+}
+
+// bind sets the keybindings and handlers of the view of the list.
+func (l *List) bind(v *View) error {
+	v.Highlight = true
+	if v.SelFgColor == ColorDefault && v.SelBgColor == ColorDefault {
+		v.SelFgColor = v.FgColor | AttrReverse
+	}
+	v.Editor = EditorFunc(l.edit)
+	v.OnMouse = l.onMouse
+
+	bindings := []struct {
+		key     Key
+		handler func(*Gui, *View) error
+	}{
+		{KeyArrowUp, l.moveHandler(-1)},
+		{KeyArrowDown, l.moveHandler(1)},
+		{KeyPgup, l.pageHandler(-1)},
+		{KeyPgdn, l.pageHandler(1)},
+		{KeyHome, l.onHome},
+		{KeyEnd, l.onEnd},
+		{KeyEnter, l.onEnter},
+		{KeySpace, l.onSpace},
+	}
+	for _, b := range bindings {
+		if err := v.SetKeybinding(b.key, ModNone, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// draw writes the shown items that fit in the view.
+func (l *List) draw(v *View) {
+	_, h := v.Size()
+	n := l.Len()
+	if l.cursor >= n {
+		l.cursor = n - 1
+	}
+	if l.cursor < 0 {
+		l.cursor = 0
+	}
+	if l.cursor < l.offset {
+		l.offset = l.cursor
+	} else if h > 0 && l.cursor >= l.offset+h {
+		l.offset = l.cursor - h + 1
+	}
+	if last := n - h; l.offset > last {
+		l.offset = last
+	}
+	if l.offset < 0 {
+		l.offset = 0
+	}
+
+	v.SetOrigin(0, 0)
+	var b strings.Builder
+	for row := 0; row < h && l.offset+row < n; row++ {
+		i := l.item(l.offset + row)
+		if row > 0 {
+			b.WriteByte('\n')
+		}
+		if l.Multi {
+			if l.selected[i] {
+				b.WriteString("[x] ")
+			} else {
+				b.WriteString("[ ] ")
+			}
+		}
+		if l.Render != nil {
+			b.WriteString(l.Render(i))
+		} else {
+			b.WriteString(l.items[i])
+		}
+	}
+	writeText(v, b.String())
+	v.SetCursor(0, l.cursor-l.offset)
+}
+
+// item returns the index of the item shown at the given position, or -1 if
+// there is none.
+func (l *List) item(pos int) int {
+	if pos < 0 || pos >= l.Len() {
+		return -1
+	}
+	if l.matches == nil {
+		return pos
+	}
+	return l.matches[pos]
+}
+
+// move moves the cursor by the given number of shown items.
+func (l *List) move(delta int) {
+	l.cursor += delta
+	if n := l.Len(); l.cursor >= n {
+		l.cursor = n - 1
+	}
+	if l.cursor < 0 {
+		l.cursor = 0
+	}
+}
+
+// moveHandler returns a handler that moves the cursor by delta items.
+func (l *List) moveHandler(delta int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		l.move(delta)
+		return nil
+	}
+}
+
+// pageHandler returns a handler that moves the cursor by the given number
+// of pages.
+func (l *List) pageHandler(pages int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		_, h := v.Size()
+		l.move(pages * h)
+		return nil
+	}
+}
+
+// onHome moves the cursor to the first item.
+func (l *List) onHome(g *Gui, v *View) error {
+	l.cursor = 0
+	return nil
+}
+
+// onEnd moves the cursor to the last item.
+func (l *List) onEnd(g *Gui, v *View) error {
+	l.move(l.Len())
+	return nil
+}
+
+// onEnter calls the OnSelect handler with the item under the cursor.
+func (l *List) onEnter(g *Gui, v *View) error {
+	if i := l.Cursor(); i >= 0 && l.OnSelect != nil {
+		return l.OnSelect(g, l, i)
+	}
+	return nil
+}
+
+// onSpace toggles the item under the cursor if Multi is true. Otherwise,
+// the space is added to the filter.
+func (l *List) onSpace(g *Gui, v *View) error {
+	if !l.Multi {
+		l.edit(v, KeySpace, 0, ModNone)
+		return nil
+	}
+	if i := l.Cursor(); i >= 0 {
+		return l.SetSelected(i, !l.selected[i])
+	}
+	return nil
+}
+
+// edit manages the text typed in the list, which is used as filter.
+func (l *List) edit(v *View, key Key, ch rune, mod Modifier) {
+	if !l.Filterable {
+		return
+	}
+	switch {
+	case ch != 0 && mod == 0:
+		l.SetFilter(l.filter + string(ch))
+	case key == KeySpace:
+		l.SetFilter(l.filter + " ")
+	case (key == KeyBackspace || key == KeyBackspace2) && l.filter != "":
+		r := []rune(l.filter)
+		l.SetFilter(string(r[:len(r)-1]))
+	case key == KeyEsc:
+		l.SetFilter("")
+	}
+}
+
+// onMouse manages the mouse events on the view of the list.
+func (l *List) onMouse(g *Gui, v *View, ev *MouseEvent) error {
+	if ev.Action != MouseActionPress {
+		return nil
+	}
+	_, h := v.Size()
+	switch ev.Button {
+	case MouseWheelUp, MouseWheelDown:
+		delta := 1
+		if ev.Button == MouseWheelUp {
+			delta = -1
+		}
+		if last := l.Len() - h; l.offset+delta >= 0 && l.offset+delta <= last {
+			l.offset += delta
+		}
+		if l.cursor < l.offset {
+			l.cursor = l.offset
+		} else if l.cursor >= l.offset+h {
+			l.cursor = l.offset + h - 1
+		}
+	case MouseLeft:
+		pos := l.offset + ev.ViewY
+		if ev.ViewY < 0 || ev.ViewY >= h || pos >= l.Len() {
+			return nil
+		}
+		l.cursor = pos
+		if l.Multi && ev.ViewX >= 0 && ev.ViewX < 3 {
+			i := l.item(pos)
+			return l.SetSelected(i, !l.selected[i])
+		}
+		if ev.Clicks == 2 {
+			return l.onEnter(g, v)
+		}
+	}
+	return nil
+}
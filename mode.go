@@ -0,0 +1,66 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+// Mode represents an input mode of the GUI. Keybindings can be registered
+// for a given mode, so they are only triggered while the GUI is in that mode.
+type Mode string
+
+// Input modes.
+const (
+	// ModeNone is the initial mode of the GUI. Keybindings registered with
+	// ModeNone apply to all modes.
+	ModeNone Mode = ""
+
+	// ModeNormal is meant for navigation. Editors do not receive keys in
+	// this mode.
+	ModeNormal Mode = "normal"
+
+	// ModeInsert is meant for text edition.
+	ModeInsert Mode = "insert"
+
+	// ModeCommand is meant for typing commands, usually in a dedicated
+	// editable view.
+	ModeCommand Mode = "command"
+)
+
+// SetMode changes the input mode of the GUI. If a mode handler has been set
+// with SetModeFunc, it is called after the mode has changed.
+func (g *Gui) SetMode(mode Mode) error {
+	if mode == g.mode {
+		return nil
+	}
+
+	from := g.mode
+	g.mode = mode
+	if g.modeHandler != nil {
+		return g.modeHandler(g, from, mode)
+	}
+	return nil
+}
+
+// Mode returns the current input mode of the GUI.
+func (g *Gui) Mode() Mode {
+	return g.mode
+}
+
+// SetModeFunc sets a handler that will be called every time the input mode
+// changes, receiving the previous and the new mode.
+func (g *Gui) SetModeFunc(handler func(g *Gui, from, to Mode) error) {
+	g.modeHandler = handler
+}
+
+// SetModeEditable configures if the Editor of the current view receives
+// the keys that do not match any keybinding while the GUI is in the given
+// mode. By default, only ModeNone, ModeInsert and ModeCommand allow
+// edition.
+func (g *Gui) SetModeEditable(mode Mode, editable bool) {
+	g.editModes[mode] = editable
+}
+
+// ModeEditable returns if the given mode allows edition.
+func (g *Gui) ModeEditable(mode Mode) bool {
+	return g.editModes[mode]
+}
@@ -0,0 +1,298 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"errors"
+	"time"
+)
+
+// doubleClickDelay is the maximum time between two clicks for them to be
+// considered consecutive.
+const doubleClickDelay = 400 * time.Millisecond
+
+// MouseAction represents the kind of a mouse event.
+type MouseAction int
+
+// Mouse actions.
+const (
+	// MouseActionPress is reported when a button is pressed or the wheel
+	// is scrolled.
+	MouseActionPress MouseAction = iota
+
+	// MouseActionRelease is reported when a button is released.
+	MouseActionRelease
+
+	// MouseActionDrag is reported when the mouse moves while a button is
+	// pressed.
+	MouseActionDrag
+
+	// MouseActionMotion is reported when the mouse moves while no button is
+	// pressed. It requires Gui.MouseMotion.
+	MouseActionMotion
+
+	// MouseActionEnter is reported to a view when the mouse enters it.
+	MouseActionEnter
+
+	// MouseActionLeave is reported to a view when the mouse leaves it.
+	MouseActionLeave
+)
+
+// MouseEvent describes a mouse event.
+type MouseEvent struct {
+	// Action is the kind of event.
+	Action MouseAction
+
+	// Button is the button that was pressed, released or is being dragged:
+	// MouseLeft, MouseMiddle, MouseRight, MouseWheelUp or MouseWheelDown.
+	// It is 0 for motion events.
+	Button Key
+
+	// Mod contains the keyboard modifiers held during the event, if the
+	// terminal reports them.
+	Mod Modifier
+
+	// Clicks is the number of consecutive clicks at the same position:
+	// 1 for a single click, 2 for a double click and 3 for a triple click.
+	// It is only set for press and release events.
+	Clicks int
+
+	// X and Y are the coordinates of the event, relative to the top-left
+	// corner of the terminal.
+	X, Y int
+
+	// ViewX and ViewY are the coordinates of the event, relative to the
+	// top-left corner of the view's content. They can be out of the view's
+	// bounds if the view is capturing the mouse or the event happens on its
+	// padding.
+	ViewX, ViewY int
+}
+
+// SetMouseBinding sets a handler that receives the details of every mouse
+// event on the given view. If viewname equals to "" (empty string) then the
+// handler will receive the events on all views, and also those that happen
+// outside of any view, in which case it is called with a nil View.
+func (g *Gui) SetMouseBinding(viewname string, handler func(*Gui, *View, *MouseEvent) error) error {
+	if handler == nil {
+		return errors.New("invalid handler")
+	}
+	kb := newMouseBinding(viewname, handler)
+	g.keybindings = append(g.keybindings, kb)
+	return nil
+}
+
+// DeleteMouseBindings deletes all mouse bindings of view.
+func (g *Gui) DeleteMouseBindings(viewname string) {
+	var s []*keybinding
+	for _, kb := range g.keybindings {
+		if kb.mouseHandler == nil || kb.viewName != viewname {
+			s = append(s, kb)
+		}
+	}
+	g.keybindings = s
+}
+
+// CaptureMouse makes the view with the given name receive all the mouse
+// events, even those that happen outside of its bounds, until a mouse button
+// is released or ReleaseMouse is called. It is usually called when a drag
+// starts.
+func (g *Gui) CaptureMouse(name string) error {
+	v, err := g.View(name)
+	if err != nil {
+		return err
+	}
+	g.mouse.capture = v
+	return nil
+}
+
+// ReleaseMouse ends the mouse capture started by CaptureMouse.
+func (g *Gui) ReleaseMouse() {
+	g.mouse.capture = nil
+}
+
+// MouseCapture returns the view that is capturing the mouse, or nil if
+// the mouse is not captured.
+func (g *Gui) MouseCapture() *View {
+	return g.mouse.capture
+}
+
+// onMouse manages mouse events. Events on scrollbars are handled by gotui.
+// Otherwise, the event is routed to the view capturing the mouse or, if
+// there is none, to the view under the mouse. The cursor of that view is
+// moved to the event's position, then the keybindings and mouse bindings
+// matching the event are executed.
+func (g *Gui) onMouse(ev *event) error {
+	mev := g.newMouseEvent(ev)
+
+	hover, err := g.ViewByPosition(mev.X, mev.Y)
+	if err != nil {
+		hover = nil
+	}
+	if err := g.updateHover(hover, mev); err != nil {
+		return err
+	}
+	if handled, err := g.onScrollbar(mev); handled || err != nil {
+		return err
+	}
+
+	if g.mouse.capture != nil && !g.mouse.capture.Visible {
+		g.mouse.capture = nil
+	}
+	if g.mouse.scroll.view != nil && !g.mouse.scroll.view.Visible {
+		g.mouse.scroll.view = nil
+	}
+
+	v := hover
+	if g.mouse.capture != nil {
+		v = g.mouse.capture
+		if mev.Action == MouseActionRelease {
+			g.mouse.capture = nil
+		}
+	}
+	if v == nil {
+		_, err := g.execMouseBindings(nil, mev)
+		return err
+	}
+
+	mev.ViewX, mev.ViewY = v.viewPosition(mev.X, mev.Y)
+	if v == hover && mev.Action != MouseActionMotion {
+		// clicks on the padding do not move the cursor
+		maxX, maxY := v.Size()
+		if mev.ViewX >= 0 && mev.ViewX < maxX && mev.ViewY >= 0 && mev.ViewY < maxY {
+			if err := v.SetCursor(mev.ViewX, mev.ViewY); err != nil {
+				return err
+			}
+		}
+		matched, err := g.execKeybindings(v, ev)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			if _, err := g.onScrollWheel(v, mev); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = g.execMouseBindings(v, mev)
+	return err
+}
+
+// updateHover keeps track of the view under the mouse, sending the
+// MouseActionLeave and MouseActionEnter events when it changes.
+func (g *Gui) updateHover(v *View, mev *MouseEvent) error {
+	prev := g.mouse.hover
+	if v == prev {
+		return nil
+	}
+	g.mouse.hover = v
+
+	if prev != nil {
+		lev := *mev
+		lev.Action = MouseActionLeave
+		lev.Clicks = 0
+		lev.ViewX, lev.ViewY = prev.viewPosition(mev.X, mev.Y)
+		if _, err := g.execMouseBindings(prev, &lev); err != nil {
+			return err
+		}
+	}
+	if v != nil {
+		eev := *mev
+		eev.Action = MouseActionEnter
+		eev.Clicks = 0
+		eev.ViewX, eev.ViewY = v.viewPosition(mev.X, mev.Y)
+		if _, err := g.execMouseBindings(v, &eev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newMouseEvent returns the MouseEvent corresponding to a mouse event,
+// keeping track of the pressed button and consecutive clicks.
+func (g *Gui) newMouseEvent(ev *event) *MouseEvent {
+	mev := &MouseEvent{
+		Button: ev.key,
+		Mod:    ev.mod &^ ModMotion,
+		X:      ev.mouseX,
+		Y:      ev.mouseY,
+	}
+
+	switch {
+	case ev.key == MouseRelease && ev.mod&ModMotion != 0:
+		mev.Action = MouseActionMotion
+		mev.Button = 0
+	case ev.key == MouseRelease:
+		mev.Action = MouseActionRelease
+		mev.Button = g.mouse.button
+		mev.Clicks = g.mouse.clicks
+		g.mouse.button = 0
+	case ev.mod&ModMotion != 0:
+		mev.Action = MouseActionDrag
+	default:
+		mev.Action = MouseActionPress
+		if ev.key == MouseWheelUp || ev.key == MouseWheelDown {
+			break
+		}
+
+		now := time.Now()
+		m := &g.mouse
+		if m.clicks > 0 && m.clicks < 3 && ev.key == m.lastButton &&
+			mev.X == m.lastX && mev.Y == m.lastY && now.Sub(m.lastClick) <= doubleClickDelay {
+			m.clicks++
+		} else {
+			m.clicks = 1
+		}
+		m.button, m.lastButton = ev.key, ev.key
+		m.lastX, m.lastY = mev.X, mev.Y
+		m.lastClick = now
+		mev.Clicks = m.clicks
+	}
+	return mev
+}
+
+// execMouseBindings executes the mouse binding handlers that match the
+// passed view. The value of matched is true if there is a match and no
+// errors.
+func (g *Gui) execMouseBindings(v *View, ev *MouseEvent) (matched bool, err error) {
+	matched = false
+	if v != nil && v.OnMouse != nil {
+		if err := v.OnMouse(g, v, ev); err != nil {
+			return false, err
+		}
+		matched = true
+	}
+	for _, kb := range g.keybindings {
+		if kb.mouseHandler == nil {
+			continue
+		}
+		if kb.matchView(v) && kb.matchMode(g.mode) {
+			if err := kb.mouseHandler(g, v, ev); err != nil {
+				return false, err
+			}
+			matched = true
+		}
+	}
+	return matched, nil
+}
+
+// viewPosition converts a position relative to the terminal into a position
+// relative to the content of the view.
+func (v *View) viewPosition(x, y int) (vx, vy int) {
+	x0, y0 := v.origin()
+	return x - x0, y - y0
+}
+
+// mouseState keeps track of the state of the mouse between events.
+type mouseState struct {
+	button       Key // button currently pressed
+	lastButton   Key // button of the last click
+	lastX, lastY int // position of the last click
+	lastClick    time.Time
+	clicks       int // number of consecutive clicks
+
+	capture *View      // view capturing the mouse
+	hover   *View      // view under the mouse
+	scroll  scrollDrag // scrollbar being dragged
+}
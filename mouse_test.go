@@ -0,0 +1,227 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewMouseEvent(t *testing.T) {
+	press := func(k Key, x, y int) *event {
+		return &event{typ: eventMouse, key: k, mouseX: x, mouseY: y}
+	}
+	drag := func(k Key, x, y int) *event {
+		return &event{typ: eventMouse, key: k, mouseX: x, mouseY: y, mod: ModMotion}
+	}
+	release := func(x, y int) *event {
+		return &event{typ: eventMouse, key: MouseRelease, mouseX: x, mouseY: y}
+	}
+	motion := func(x, y int) *event {
+		return &event{typ: eventMouse, key: MouseRelease, mouseX: x, mouseY: y, mod: ModMotion}
+	}
+	// wait moves the last click back in time, as if the delay had passed
+	wait := func(g *Gui) {
+		g.mouse.lastClick = g.mouse.lastClick.Add(-2 * doubleClickDelay)
+	}
+
+	type step struct {
+		ev     *event
+		before func(g *Gui)
+		action MouseAction
+		button Key
+		clicks int
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"click", []step{
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 1},
+			{ev: release(1, 1), action: MouseActionRelease, button: MouseLeft, clicks: 1},
+		}},
+		{"drag", []step{
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 1},
+			{ev: drag(MouseLeft, 2, 1), action: MouseActionDrag, button: MouseLeft},
+			{ev: drag(MouseLeft, 3, 2), action: MouseActionDrag, button: MouseLeft},
+			{ev: release(3, 2), action: MouseActionRelease, button: MouseLeft, clicks: 1},
+		}},
+		{"motion", []step{
+			{ev: motion(4, 4), action: MouseActionMotion},
+		}},
+		{"release without press", []step{
+			{ev: release(1, 1), action: MouseActionRelease},
+		}},
+		{"double and triple click", []step{
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 1},
+			{ev: release(1, 1), action: MouseActionRelease, button: MouseLeft, clicks: 1},
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 2},
+			{ev: release(1, 1), action: MouseActionRelease, button: MouseLeft, clicks: 2},
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 3},
+			{ev: release(1, 1), action: MouseActionRelease, button: MouseLeft, clicks: 3},
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 1},
+		}},
+		{"clicks at different positions", []step{
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 1},
+			{ev: press(MouseLeft, 2, 1), action: MouseActionPress, button: MouseLeft, clicks: 1},
+			{ev: press(MouseLeft, 2, 2), action: MouseActionPress, button: MouseLeft, clicks: 1},
+		}},
+		{"clicks with different buttons", []step{
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 1},
+			{ev: press(MouseRight, 1, 1), action: MouseActionPress, button: MouseRight, clicks: 1},
+			{ev: release(1, 1), action: MouseActionRelease, button: MouseRight, clicks: 1},
+		}},
+		{"clicks after the delay", []step{
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 1},
+			{ev: press(MouseLeft, 1, 1), before: wait, action: MouseActionPress, button: MouseLeft, clicks: 1},
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 2},
+		}},
+		{"wheel is not a click", []step{
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 1},
+			{ev: press(MouseWheelDown, 1, 1), action: MouseActionPress, button: MouseWheelDown},
+			{ev: press(MouseWheelUp, 1, 1), action: MouseActionPress, button: MouseWheelUp},
+			{ev: press(MouseLeft, 1, 1), action: MouseActionPress, button: MouseLeft, clicks: 2},
+		}},
+	}
+	for _, tt := range tests {
+		g := &Gui{}
+		for i, s := range tt.steps {
+			if s.before != nil {
+				s.before(g)
+			}
+			mev := g.newMouseEvent(s.ev)
+			if mev.Action != s.action || mev.Button != s.button || mev.Clicks != s.clicks {
+				t.Errorf("%s: step %d: got action %v, button %v, clicks %d, want action %v, button %v, clicks %d",
+					tt.name, i, mev.Action, mev.Button, mev.Clicks, s.action, s.button, s.clicks)
+			}
+			if mev.X != s.ev.mouseX || mev.Y != s.ev.mouseY {
+				t.Errorf("%s: step %d: got position %d,%d, want %d,%d",
+					tt.name, i, mev.X, mev.Y, s.ev.mouseX, s.ev.mouseY)
+			}
+		}
+	}
+}
+
+func TestNewMouseEventMod(t *testing.T) {
+	g := &Gui{}
+	mev := g.newMouseEvent(&event{typ: eventMouse, key: MouseLeft, mod: ModMotion | ModCtrl})
+	if mev.Action != MouseActionDrag || mev.Mod != ModCtrl {
+		t.Errorf("got action %v, mod %v, want action %v, mod %v", mev.Action, mev.Mod, MouseActionDrag, ModCtrl)
+	}
+	if !g.mouse.lastClick.IsZero() {
+		t.Errorf("drag recorded as a click at %v", g.mouse.lastClick)
+	}
+}
+
+// mouseRecorder records the mouse events received by the views.
+type mouseRecorder []string
+
+func (r *mouseRecorder) handler(g *Gui, v *View, ev *MouseEvent) error {
+	names := map[MouseAction]string{
+		MouseActionPress:   "press",
+		MouseActionRelease: "release",
+		MouseActionDrag:    "drag",
+		MouseActionMotion:  "motion",
+		MouseActionEnter:   "enter",
+		MouseActionLeave:   "leave",
+	}
+	*r = append(*r, v.Name()+":"+names[ev.Action])
+	return nil
+}
+
+func TestMouseCapture(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []*event
+		hide    bool
+		want    []string
+		capture string
+	}{
+		{
+			"drag outside of the view",
+			[]*event{
+				{typ: eventMouse, key: MouseLeft, mouseX: 5, mouseY: 5},
+				{typ: eventMouse, key: MouseLeft, mouseX: 25, mouseY: 5, mod: ModMotion},
+			},
+			false,
+			[]string{"a:enter", "a:press", "a:leave", "b:enter", "a:drag"},
+			"a",
+		},
+		{
+			"release ends the capture",
+			[]*event{
+				{typ: eventMouse, key: MouseLeft, mouseX: 5, mouseY: 5},
+				{typ: eventMouse, key: MouseLeft, mouseX: 25, mouseY: 5, mod: ModMotion},
+				{typ: eventMouse, key: MouseRelease, mouseX: 25, mouseY: 5},
+				{typ: eventMouse, key: MouseLeft, mouseX: 25, mouseY: 5},
+			},
+			false,
+			[]string{"a:enter", "a:press", "a:leave", "b:enter", "a:drag", "a:release", "b:press"},
+			"",
+		},
+		{
+			"hidden view loses the capture",
+			[]*event{
+				{typ: eventMouse, key: MouseLeft, mouseX: 5, mouseY: 5},
+				{typ: eventMouse, key: MouseLeft, mouseX: 25, mouseY: 5, mod: ModMotion},
+			},
+			true,
+			[]string{"a:enter", "a:press", "a:leave", "b:enter", "b:drag"},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		g := &Gui{maxX: 40, maxY: 20}
+		var rec mouseRecorder
+		for _, name := range []string{"a", "b"} {
+			x0 := 0
+			if name == "b" {
+				x0 = 20
+			}
+			v, err := g.SetView(name, x0, 0, x0+10, 10)
+			if err != ErrUnknownView {
+				t.Fatal(err)
+			}
+			v.OnMouse = rec.handler
+		}
+		g.SetMouseBinding("a", func(g *Gui, v *View, ev *MouseEvent) error {
+			if ev.Action == MouseActionPress {
+				if err := g.CaptureMouse("a"); err != nil {
+					return err
+				}
+				v.Visible = !tt.hide
+			}
+			return nil
+		})
+
+		for _, ev := range tt.events {
+			if err := g.onMouse(ev); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+		}
+
+		if !reflect.DeepEqual([]string(rec), tt.want) {
+			t.Errorf("%s: got events %v, want %v", tt.name, rec, tt.want)
+		}
+
+		capture := ""
+		if v := g.MouseCapture(); v != nil {
+			capture = v.Name()
+		}
+		if capture != tt.capture {
+			t.Errorf("%s: got capture %q, want %q", tt.name, capture, tt.capture)
+		}
+	}
+}
+
+func TestCaptureMouseUnknownView(t *testing.T) {
+	g := &Gui{}
+	if err := g.CaptureMouse("none"); err != ErrUnknownView {
+		t.Errorf("got error %v, want %v", err, ErrUnknownView)
+	}
+	if g.MouseCapture() != nil {
+		t.Error("unknown view captures the mouse")
+	}
+}
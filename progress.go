@@ -0,0 +1,262 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ProgressBar is a widget that shows the progress of a task. Its bar is
+// drawn with eighths of a cell, so it moves smoothly. If the task has no
+// known length, the bar is Indeterminate and a block bounces from side to
+// side instead.
+//
+// The bar is drawn according to the time, so indeterminate bars and the
+// ETA are only updated when the GUI is redrawn. Applications showing them
+// must redraw it periodically, for instance calling Gui.Update from a
+// time.Ticker. A single redraw updates all the widgets.
+type ProgressBar struct {
+	widget
+
+	// Title is the title of the view of the bar.
+	Title string
+
+	// Label is the text shown before the bar.
+	Label string
+
+	// If Indeterminate is true, the length of the task is unknown and the
+	// value is ignored.
+	Indeterminate bool
+
+	// If ShowPercent is true, the percentage is shown after the bar.
+	ShowPercent bool
+
+	// If ShowETA is true, the estimated time left is shown after the bar.
+	// It is computed from the time elapsed since the bar was created or
+	// reset.
+	ShowETA bool
+
+	// FgColor and BgColor are the colors of the filled and the empty parts
+	// of the bar. If FgColor is ColorDefault, the color of the view is
+	// used.
+	FgColor, BgColor Attribute
+
+	value float64
+	start time.Time
+}
+
+// NewProgressBar returns a new ProgressBar with the given name and
+// position.
+func NewProgressBar(name string, x0, y0, x1, y1 int) *ProgressBar {
+	return &ProgressBar{
+		widget: widget{name: name, x0: x0, y0: y0, x1: x1, y1: y1},
+		start:  time.Now(),
+	}
+}
+
+// SetValue sets the progress of the task, from 0 to 1. Values out of the
+// range are clamped.
+func (p *ProgressBar) SetValue(value float64) {
+	p.value = math.Max(0, math.Min(1, value))
+}
+
+// Value returns the progress of the task, from 0 to 1.
+func (p *ProgressBar) Value() float64 {
+	return p.value
+}
+
+// Reset sets the progress to 0 and restarts the time used to compute the
+// ETA.
+func (p *ProgressBar) Reset() {
+	p.value = 0
+	p.start = time.Now()
+}
+
+// ETA returns the estimated time left to complete the task, assuming that
+// it progresses at a constant rate. It returns false if it cannot be
+// estimated yet.
+func (p *ProgressBar) ETA() (time.Duration, bool) {
+	if p.Indeterminate || p.value <= 0 {
+		return 0, false
+	}
+	elapsed := time.Since(p.start)
+	return time.Duration(float64(elapsed) * (1 - p.value) / p.value), true
+}
+
+// Layout draws the progress bar.
+func (p *ProgressBar) Layout(g *Gui) error {
+	v, err := g.SetView(p.name, p.x0, p.y0, p.x1, p.y1)
+	if err != nil {
+		if err != ErrUnknownView {
+			return err
+		}
+		v.Focusable = false
+	}
+	v.Title = p.Title
+	v.Highlight = false
+
+	fgColor := p.FgColor
+	if fgColor == ColorDefault {
+		fgColor = v.FgColor
+	}
+
+	info := ""
+	if p.ShowPercent && !p.Indeterminate {
+		info = fmt.Sprintf("%3d%%", int(math.Round(p.value*100)))
+	}
+	if p.ShowETA {
+		if eta, ok := p.ETA(); ok && p.value < 1 {
+			if info != "" {
+				info += " "
+			}
+			info += "ETA " + eta.Round(time.Second).String()
+		}
+	}
+
+	drawBar(g, v, p.Label, info, func(width int) []cell {
+		if p.Indeterminate {
+			return bounceCells(g, time.Since(p.start), width, fgColor, p.BgColor)
+		}
+		return barCells(g, p.value, width, fgColor, p.BgColor)
+	})
+	return nil
+}
+
+// Runes used to draw bars with eighths of a cell. leftBlocks[i] fills the
+// left i eighths of a cell and lowerBlocks[i] the lower i eighths.
+var (
+	leftBlocks  = []rune(" ▏▎▍▌▋▊▉█")
+	lowerBlocks = []rune(" ▁▂▃▄▅▆▇█")
+)
+
+// Runes used to draw bars when Gui.ASCII is true.
+const (
+	asciiFilled = '#'
+	asciiEmpty  = '.'
+)
+
+// bounceDuration is the time taken by the block of an indeterminate bar to
+// cross the bar.
+const bounceDuration = time.Second
+
+// drawBar writes the label, the bar returned by bar for the given width and
+// the info text on the middle row of the view. The rest of the rows only
+// show the bar. The texts are dropped if there is no room for the bar.
+func drawBar(g *Gui, v *View, label, info string, bar func(width int) []cell) {
+	w, h := v.Size()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	if label != "" {
+		label += " "
+	}
+	if info != "" {
+		info = " " + info
+	}
+	lw, iw := TextWidth(label), TextWidth(info)
+	if w-lw-iw < 1 {
+		info, iw = "", 0
+	}
+	if w-lw-iw < 1 {
+		label, lw = "", 0
+	}
+
+	cells := bar(w - lw - iw)
+	lines := make([][]cell, h)
+	for y := range lines {
+		text, infoText := "", ""
+		if y == h/2 {
+			text, infoText = label, info
+		}
+		line := make([]cell, 0, w)
+		line = append(line, alignCells(g, text, lw, AlignLeft, v.FgColor, v.BgColor)...)
+		line = append(line, cells...)
+		line = append(line, alignCells(g, infoText, iw, AlignLeft, v.FgColor, v.BgColor)...)
+		lines[y] = line
+	}
+	v.SetOrigin(0, 0)
+	setLines(v, lines)
+}
+
+// barCells returns the cells of a horizontal bar of the given width filled
+// up to value, from 0 to 1.
+func barCells(g *Gui, value float64, width int, fgColor, bgColor Attribute) []cell {
+	cells := make([]cell, width)
+	if g.ASCII {
+		filled := int(math.Round(value * float64(width)))
+		for i := range cells {
+			cells[i] = cell{chr: asciiEmpty, fgColor: fgColor, bgColor: bgColor}
+			if i < filled {
+				cells[i].chr = asciiFilled
+			}
+		}
+		return cells
+	}
+
+	eighths := int(math.Round(value * float64(width*8)))
+	for i := range cells {
+		n := eighths - i*8
+		if n < 0 {
+			n = 0
+		} else if n > 8 {
+			n = 8
+		}
+		cells[i] = cell{chr: leftBlocks[n], fgColor: fgColor, bgColor: bgColor}
+	}
+	return cells
+}
+
+// bounceCells returns the cells of an indeterminate bar of the given width,
+// whose block moves back and forth every bounceDuration.
+func bounceCells(g *Gui, elapsed time.Duration, width int, fgColor, bgColor Attribute) []cell {
+	size := width / 4
+	if size < 1 {
+		size = 1
+	}
+	unit := 8
+	if g.ASCII {
+		unit = 1
+	}
+	track := (width - size) * unit
+
+	phase := float64(elapsed%(2*bounceDuration)) / float64(bounceDuration)
+	if phase > 1 {
+		phase = 2 - phase
+	}
+	start := int(math.Round(phase * float64(track)))
+	end := start + size*unit
+
+	cells := make([]cell, width)
+	for i := range cells {
+		// part of the cell covered by the block, in units
+		a, b := start-i*unit, end-i*unit
+		if a < 0 {
+			a = 0
+		}
+		if b > unit {
+			b = unit
+		}
+
+		c := cell{chr: ' ', fgColor: fgColor, bgColor: bgColor}
+		switch {
+		case g.ASCII && a < b:
+			c.chr = asciiFilled
+		case g.ASCII:
+			c.chr = asciiEmpty
+		case a >= b:
+		case a == 0:
+			c.chr = leftBlocks[b]
+		default:
+			// the block covers the right part of the cell, which is drawn
+			// reversing the rune that covers the empty left part
+			c.chr = leftBlocks[a]
+			c.fgColor |= AttrReverse
+		}
+		cells[i] = c
+	}
+	return cells
+}
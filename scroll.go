@@ -0,0 +1,115 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+// ScrollUp scrolls the view n lines up. Lines are counted as displayed, so
+// every wrapped part of a line counts as a line.
+func (v *View) ScrollUp(n int) {
+	v.scrollTo(v.oy - n)
+}
+
+// ScrollDown scrolls the view n lines down, without going past the end of
+// its content.
+func (v *View) ScrollDown(n int) {
+	v.scrollTo(v.oy + n)
+}
+
+// PageUp scrolls the view one page up.
+func (v *View) PageUp() {
+	_, maxY := v.Size()
+	v.ScrollUp(maxY)
+}
+
+// PageDown scrolls the view one page down.
+func (v *View) PageDown() {
+	_, maxY := v.Size()
+	v.ScrollDown(maxY)
+}
+
+// ScrollToTop scrolls the view to the beginning of its content.
+func (v *View) ScrollToTop() {
+	v.scrollTo(0)
+}
+
+// ScrollToBottom scrolls the view to the end of its content.
+func (v *View) ScrollToBottom() {
+	_, total := v.scrollHeight()
+	v.scrollTo(total)
+}
+
+// ScrollTo scrolls the view so the given line of its buffer is displayed
+// at the top, or as close to the top as the content allows.
+func (v *View) ScrollTo(line int) {
+	v.scrollHeight()
+	for i, vline := range v.viewLines {
+		if vline.linesY >= line {
+			v.scrollTo(i)
+			return
+		}
+	}
+	v.ScrollToBottom()
+}
+
+// scrollTo sets the y-origin of the view to the given displayed line,
+// clamped to its content.
+func (v *View) scrollTo(y int) {
+	visible, total := v.scrollHeight()
+	if y > total-visible {
+		y = total - visible
+	}
+	if y < 0 {
+		y = 0
+	}
+	v.oy = y
+}
+
+// scrollHeight returns the number of visible lines of the view and the
+// number of lines of its content, as displayed.
+func (v *View) scrollHeight() (visible, total int) {
+	maxX, maxY := v.Size()
+	if v.tainted && maxX > 0 {
+		v.updateViewLines(maxX)
+	}
+	return maxY, len(v.viewLines)
+}
+
+// SetScrollKeybindings sets the keybindings that allow to scroll the given
+// view: the arrow keys, PgUp, PgDn, Home, End and the mouse wheel. If
+// viewname equals to "" (empty string) then the keybindings apply to all
+// views. Scrolling up disables the Autoscroll of the view.
+func (g *Gui) SetScrollKeybindings(viewname string) error {
+	bindings := []struct {
+		key    Key
+		up     bool
+		scroll func(v *View)
+	}{
+		{KeyArrowUp, true, func(v *View) { v.ScrollUp(1) }},
+		{KeyArrowDown, false, func(v *View) { v.ScrollDown(1) }},
+		{KeyPgup, true, (*View).PageUp},
+		{KeyPgdn, false, (*View).PageDown},
+		{KeyHome, true, (*View).ScrollToTop},
+		{KeyEnd, false, (*View).ScrollToBottom},
+		{MouseWheelUp, true, func(v *View) { v.ScrollUp(1) }},
+		{MouseWheelDown, false, func(v *View) { v.ScrollDown(1) }},
+	}
+
+	for _, b := range bindings {
+		b := b
+		handler := func(g *Gui, v *View) error {
+			if v == nil {
+				return nil
+			}
+			if b.up {
+				v.Autoscroll = false
+			}
+			b.scroll(v)
+			return nil
+		}
+		if err := g.SetKeybinding(viewname, b.key, ModNone, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+// scrollbar describes the geometry of a scrollbar of a view.
+type scrollbar struct {
+	vertical bool
+
+	x, y   int // absolute position of the start of the track
+	length int // length of the track
+
+	thumb, size int // position of the thumb in the track and its length
+
+	pos, visible, total int // origin, visible and total lines or columns
+}
+
+// scrollbarInside returns if the scrollbars of the view are drawn inside
+// the view, instead of on its frame.
+func (v *View) scrollbarInside() bool {
+	return v.ScrollbarInside || !v.Frame
+}
+
+// scrollbar returns the scrollbar of the view in the given direction. The
+// value of ok is false if the view has no scrollbar in that direction or
+// its content fits in the view.
+func (v *View) scrollbar(vertical bool) (sb scrollbar, ok bool) {
+	maxX, maxY := v.Size()
+	if maxX <= 0 || maxY <= 0 {
+		return scrollbar{}, false
+	}
+	if v.tainted {
+		v.updateViewLines(maxX)
+	}
+
+	sb.vertical = vertical
+	if vertical {
+		if !v.VScrollbar {
+			return scrollbar{}, false
+		}
+		sb.x, sb.y, sb.length = v.x1, v.y0+1, v.y1-v.y0-1
+		if v.scrollbarInside() {
+			sb.x--
+			if v.HScrollbar && !v.Wrap {
+				sb.length--
+			}
+		}
+		sb.pos, sb.visible, sb.total = v.oy, maxY, len(v.viewLines)
+	} else {
+		if !v.HScrollbar || v.Wrap {
+			return scrollbar{}, false
+		}
+		sb.x, sb.y, sb.length = v.x0+1, v.y1, v.x1-v.x0-1
+		if v.scrollbarInside() {
+			sb.y--
+			if v.VScrollbar {
+				sb.length--
+			}
+		}
+		sb.pos, sb.visible = v.ox, maxX
+		for _, vline := range v.viewLines {
+			if len(vline.line) > sb.total {
+				sb.total = len(vline.line)
+			}
+		}
+	}
+
+	if sb.length <= 0 || (sb.total <= sb.visible && sb.pos == 0) {
+		return scrollbar{}, false
+	}
+	if sb.total < sb.pos+sb.visible {
+		sb.total = sb.pos + sb.visible
+	}
+
+	sb.size = sb.length * sb.visible / sb.total
+	if sb.size < 1 {
+		sb.size = 1
+	}
+	sb.thumb = sb.positionToThumb(sb.pos)
+	return sb, true
+}
+
+// positionToThumb returns the position of the thumb corresponding to the
+// given origin.
+func (sb scrollbar) positionToThumb(pos int) int {
+	if sb.total <= sb.visible {
+		return 0
+	}
+	return (pos*(sb.length-sb.size)*2 + (sb.total - sb.visible)) / ((sb.total - sb.visible) * 2)
+}
+
+// thumbToPosition returns the origin corresponding to the given position
+// of the thumb.
+func (sb scrollbar) thumbToPosition(thumb int) int {
+	if sb.length <= sb.size {
+		return 0
+	}
+	if thumb < 0 {
+		thumb = 0
+	} else if thumb > sb.length-sb.size {
+		thumb = sb.length - sb.size
+	}
+	return (thumb*(sb.total-sb.visible)*2 + (sb.length - sb.size)) / ((sb.length - sb.size) * 2)
+}
+
+// offset returns the position of the given point in the track, or -1 if
+// the point is not on the scrollbar.
+func (sb scrollbar) offset(x, y int) int {
+	if sb.vertical {
+		if x != sb.x || y < sb.y || y >= sb.y+sb.length {
+			return -1
+		}
+		return y - sb.y
+	}
+	if y != sb.y || x < sb.x || x >= sb.x+sb.length {
+		return -1
+	}
+	return x - sb.x
+}
+
+// setScrollPosition scrolls the view to the given origin, clamped to its
+// content.
+func (v *View) setScrollPosition(sb scrollbar, pos int) error {
+	if pos > sb.total-sb.visible {
+		pos = sb.total - sb.visible
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	if sb.vertical {
+		return v.SetOrigin(v.ox, pos)
+	}
+	return v.SetOrigin(pos, v.oy)
+}
+
+// drawScrollbars draws the scrollbars of the view.
+func (g *Gui) drawScrollbars(v *View) error {
+	for _, vertical := range []bool{true, false} {
+		sb, ok := v.scrollbar(vertical)
+		if !ok {
+			continue
+		}
+		if err := g.drawScrollbar(v, sb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawScrollbar draws a scrollbar. The track is only drawn inside the view,
+// on the frame the edge is used as track.
+func (g *Gui) drawScrollbar(v *View, sb scrollbar) error {
+	runeThumb, runeTrack := '█', '│'
+	if !sb.vertical {
+		runeTrack = '─'
+	}
+	if g.ASCII {
+		runeThumb, runeTrack = '#', '|'
+		if !sb.vertical {
+			runeTrack = '-'
+		}
+	}
+
+	fgColor, bgColor := v.FgColor, v.BgColor
+	if !v.scrollbarInside() {
+		fgColor, bgColor = g.frameColors(v)
+	}
+
+	for i := 0; i < sb.length; i++ {
+		x, y := sb.x, sb.y+i
+		if !sb.vertical {
+			x, y = sb.x+i, sb.y
+		}
+		if x < 0 || y < 0 || x >= g.maxX || y >= g.maxY {
+			continue
+		}
+
+		ch := runeTrack
+		if i >= sb.thumb && i < sb.thumb+sb.size {
+			ch = runeThumb
+		} else if !v.scrollbarInside() {
+			continue
+		}
+		if err := g.SetRune(x, y, ch, fgColor, bgColor); err != nil {
+			return err
+		}
+		g.clearFrameLines(x, y, x, y)
+	}
+	return nil
+}
+
+// scrollbarByPosition returns the view and the scrollbar at the given
+// position, if any.
+func (g *Gui) scrollbarByPosition(x, y int) (*View, scrollbar, bool) {
+	for i := len(g.views); i > 0; i-- {
+		v := g.views[i-1]
+		if !v.Visible || x < v.x0 || x > v.x1 || y < v.y0 || y > v.y1 {
+			continue
+		}
+		for _, vertical := range []bool{true, false} {
+			if sb, ok := v.scrollbar(vertical); ok && sb.offset(x, y) != -1 {
+				return v, sb, true
+			}
+		}
+		break
+	}
+	return nil, scrollbar{}, false
+}
+
+// onScrollbar manages the mouse events on scrollbars. Clicking on the track
+// scrolls a page and dragging the thumb scrolls the view. The value of
+// handled is true if the event has been consumed by a scrollbar.
+func (g *Gui) onScrollbar(mev *MouseEvent) (handled bool, err error) {
+	drag := &g.mouse.scroll
+	if drag.view != nil {
+		sb, ok := drag.view.scrollbar(drag.vertical)
+		switch {
+		case mev.Action == MouseActionRelease:
+			drag.view = nil
+		case mev.Action == MouseActionDrag && ok:
+			off := mev.Y - sb.y
+			if !sb.vertical {
+				off = mev.X - sb.x
+			}
+			return true, drag.view.setScrollPosition(sb, sb.thumbToPosition(off-drag.grab))
+		}
+		return true, nil
+	}
+
+	if mev.Action != MouseActionPress || mev.Button != MouseLeft || g.mouse.capture != nil {
+		return false, nil
+	}
+	v, sb, ok := g.scrollbarByPosition(mev.X, mev.Y)
+	if !ok {
+		return false, nil
+	}
+
+	off := sb.offset(mev.X, mev.Y)
+	switch {
+	case off < sb.thumb:
+		return true, v.setScrollPosition(sb, sb.pos-sb.visible)
+	case off >= sb.thumb+sb.size:
+		return true, v.setScrollPosition(sb, sb.pos+sb.visible)
+	}
+	*drag = scrollDrag{view: v, vertical: sb.vertical, grab: off - sb.thumb}
+	return true, nil
+}
+
+// onScrollWheel scrolls the view with the mouse wheel if it has a vertical
+// scrollbar. The value of handled is true if the view has been scrolled.
+func (g *Gui) onScrollWheel(v *View, mev *MouseEvent) (handled bool, err error) {
+	if mev.Action != MouseActionPress || (mev.Button != MouseWheelUp && mev.Button != MouseWheelDown) {
+		return false, nil
+	}
+	sb, ok := v.scrollbar(true)
+	if !ok {
+		return false, nil
+	}
+	if mev.Button == MouseWheelUp {
+		return true, v.setScrollPosition(sb, sb.pos-1)
+	}
+	return true, v.setScrollPosition(sb, sb.pos+1)
+}
+
+// scrollDrag keeps track of the scrollbar thumb being dragged.
+type scrollDrag struct {
+	view     *View
+	vertical bool
+	grab     int // position of the mouse relative to the thumb
+}
@@ -0,0 +1,137 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestScrollbarThumb(t *testing.T) {
+	tests := []struct {
+		name                         string
+		length, size, visible, total int
+		pos, thumb                   int
+	}{
+		{"first position", 10, 5, 10, 20, 0, 0},
+		{"middle position", 10, 5, 10, 20, 5, 3},
+		{"last position", 10, 5, 10, 20, 10, 5},
+		{"long content first", 10, 1, 10, 1000, 0, 0},
+		{"long content second", 10, 1, 10, 1000, 1, 0},
+		{"long content before last", 10, 1, 10, 1000, 989, 9},
+		{"long content last", 10, 1, 10, 1000, 990, 9},
+		{"content as long as the view", 10, 10, 10, 10, 0, 0},
+		{"content shorter than the view", 10, 10, 10, 4, 0, 0},
+		{"one-cell track first", 1, 1, 1, 50, 0, 0},
+		{"one-cell track last", 1, 1, 1, 50, 49, 0},
+	}
+	for _, tt := range tests {
+		sb := scrollbar{length: tt.length, size: tt.size, visible: tt.visible, total: tt.total}
+		if got := sb.positionToThumb(tt.pos); got != tt.thumb {
+			t.Errorf("%s: positionToThumb(%d) = %d, want %d", tt.name, tt.pos, got, tt.thumb)
+		}
+	}
+}
+
+func TestScrollbarPosition(t *testing.T) {
+	tests := []struct {
+		name                         string
+		length, size, visible, total int
+		thumb, pos                   int
+	}{
+		{"first position", 10, 5, 10, 20, 0, 0},
+		{"middle position", 10, 5, 10, 20, 3, 6},
+		{"last position", 10, 5, 10, 20, 5, 10},
+		{"before the track", 10, 5, 10, 20, -3, 0},
+		{"after the track", 10, 5, 10, 20, 8, 10},
+		{"long content last", 10, 1, 10, 1000, 9, 990},
+		{"content shorter than the view", 10, 10, 10, 4, 3, 0},
+		{"one-cell track", 1, 1, 1, 50, 0, 0},
+		{"one-cell track after the track", 1, 1, 1, 50, 1, 0},
+	}
+	for _, tt := range tests {
+		sb := scrollbar{length: tt.length, size: tt.size, visible: tt.visible, total: tt.total}
+		if got := sb.thumbToPosition(tt.thumb); got != tt.pos {
+			t.Errorf("%s: thumbToPosition(%d) = %d, want %d", tt.name, tt.thumb, got, tt.pos)
+		}
+	}
+}
+
+func TestScrollbarRoundTrip(t *testing.T) {
+	for length := 1; length <= 12; length++ {
+		for total := 1; total <= 40; total++ {
+			visible := 8
+			if total <= visible {
+				continue
+			}
+			size := length * visible / total
+			if size < 1 {
+				size = 1
+			}
+			sb := scrollbar{length: length, size: size, visible: visible, total: total}
+			for thumb := 0; thumb <= length-size; thumb++ {
+				pos := sb.thumbToPosition(thumb)
+				if pos < 0 || pos > total-visible {
+					t.Errorf("%+v: thumbToPosition(%d) = %d out of range", sb, thumb, pos)
+				}
+				// every thumb position reaches a different origin when there
+				// are more origins than thumb positions
+				if length > size && total-visible >= length-size {
+					if got := sb.positionToThumb(pos); got != thumb {
+						t.Errorf("%+v: positionToThumb(thumbToPosition(%d)) = %d", sb, thumb, got)
+					}
+				}
+			}
+			// and the other way around
+			for pos := 0; pos <= total-visible; pos++ {
+				if length > size && total-visible <= length-size {
+					if got := sb.thumbToPosition(sb.positionToThumb(pos)); got != pos {
+						t.Errorf("%+v: thumbToPosition(positionToThumb(%d)) = %d", sb, pos, got)
+					}
+				}
+			}
+			if got := sb.positionToThumb(total - visible); got != length-size {
+				t.Errorf("%+v: last position has thumb %d, want %d", sb, got, length-size)
+			}
+		}
+	}
+}
+
+func TestViewScrollbar(t *testing.T) {
+	tests := []struct {
+		name        string
+		lines       int
+		oy          int
+		ok          bool
+		thumb, size int
+	}{
+		{"content shorter than the view", 3, 0, false, 0, 0},
+		{"content as long as the view", 8, 0, false, 0, 0},
+		{"first position", 16, 0, true, 0, 4},
+		{"last position", 16, 8, true, 4, 4},
+		{"scrolled past the content", 4, 2, true, 2, 6},
+	}
+	for _, tt := range tests {
+		g := &Gui{}
+		v, err := g.SetView("v", 0, 0, 10, 9)
+		if err != ErrUnknownView {
+			t.Fatal(err)
+		}
+		v.VScrollbar = true
+		fmt.Fprint(v, strings.Repeat("line\n", tt.lines-1)+"line")
+		v.oy = tt.oy
+
+		sb, ok := v.scrollbar(true)
+		if ok != tt.ok {
+			t.Errorf("%s: got ok %v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		if ok && (sb.thumb != tt.thumb || sb.size != tt.size) {
+			t.Errorf("%s: got thumb %d, size %d, want thumb %d, size %d",
+				tt.name, sb.thumb, sb.size, tt.thumb, tt.size)
+		}
+	}
+}
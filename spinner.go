@@ -0,0 +1,153 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// SpinnerFrames is the sequence of texts shown by a Spinner.
+type SpinnerFrames []string
+
+// Predefined spinner frames.
+var (
+	SpinnerDots    = SpinnerFrames{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	SpinnerCircle  = SpinnerFrames{"◐", "◓", "◑", "◒"}
+	SpinnerQuarter = SpinnerFrames{"▖", "▘", "▝", "▗"}
+	SpinnerBar     = SpinnerFrames{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃", "▂"}
+	SpinnerArrows  = SpinnerFrames{"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"}
+
+	// SpinnerASCII is used when Gui.ASCII is true and the frames of the
+	// spinner are not ASCII.
+	SpinnerASCII = SpinnerFrames{"|", "/", "-", "\\"}
+)
+
+// isASCII returns if all the frames only contain ASCII characters.
+func (f SpinnerFrames) isASCII() bool {
+	for _, s := range f {
+		for i := 0; i < len(s); i++ {
+			if s[i] >= utf8.RuneSelf {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Spinner is a widget that shows an animation to indicate that a task of
+// unknown length is running, followed by a label.
+//
+// The frame is chosen according to the time, so it only changes when the
+// GUI is redrawn. Applications showing spinners must redraw it
+// periodically, for instance calling Gui.Update from a time.Ticker. A
+// single redraw updates all the widgets.
+type Spinner struct {
+	widget
+
+	// Title is the title of the view of the spinner.
+	Title string
+
+	// Label is the text shown after the spinner.
+	Label string
+
+	// Frames are the texts shown in turn. They default to SpinnerDots.
+	Frames SpinnerFrames
+
+	// Interval is the time each frame is shown. It defaults to 100ms.
+	Interval time.Duration
+
+	// FgColor is the color of the frames. If it is ColorDefault, the color
+	// of the view is used.
+	FgColor Attribute
+
+	start   time.Time
+	running bool
+}
+
+// NewSpinner returns a new running Spinner with the given name and
+// position.
+func NewSpinner(name string, x0, y0, x1, y1 int) *Spinner {
+	return &Spinner{
+		widget:   widget{name: name, x0: x0, y0: y0, x1: x1, y1: y1},
+		Frames:   SpinnerDots,
+		Interval: 100 * time.Millisecond,
+		start:    time.Now(),
+		running:  true,
+	}
+}
+
+// Start starts the animation from its first frame.
+func (s *Spinner) Start() {
+	s.start = time.Now()
+	s.running = true
+}
+
+// Stop stops the animation. A stopped spinner only shows its label.
+func (s *Spinner) Stop() {
+	s.running = false
+}
+
+// Running returns if the animation is running.
+func (s *Spinner) Running() bool {
+	return s.running
+}
+
+// frame returns the text of the current frame.
+func (s *Spinner) frame(g *Gui) string {
+	frames := s.Frames
+	if g.ASCII && !frames.isASCII() {
+		frames = SpinnerASCII
+	}
+	if !s.running || len(frames) == 0 {
+		return ""
+	}
+	i := 0
+	if s.Interval > 0 {
+		i = int(time.Since(s.start) / s.Interval % time.Duration(len(frames)))
+	}
+	return frames[i]
+}
+
+// Layout draws the spinner.
+func (s *Spinner) Layout(g *Gui) error {
+	v, err := g.SetView(s.name, s.x0, s.y0, s.x1, s.y1)
+	if err != nil {
+		if err != ErrUnknownView {
+			return err
+		}
+		v.Focusable = false
+	}
+	v.Title = s.Title
+	v.Highlight = false
+
+	w, h := v.Size()
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+	fgColor := s.FgColor
+	if fgColor == ColorDefault {
+		fgColor = v.FgColor
+	}
+
+	var line []cell
+	if frame := s.frame(g); frame != "" {
+		line = append(line, alignCells(g, frame, TextWidth(frame), AlignLeft, fgColor, v.BgColor)...)
+		if s.Label != "" {
+			line = append(line, cell{chr: ' ', fgColor: v.FgColor, bgColor: v.BgColor})
+		}
+	}
+	if rest := w - len(line); rest > 0 {
+		line = append(line, alignCells(g, s.Label, rest, AlignLeft, v.FgColor, v.BgColor)...)
+	} else {
+		line = line[:w]
+	}
+
+	lines := make([][]cell, h/2+1)
+	lines[h/2] = line
+	v.SetOrigin(0, 0)
+	setLines(v, lines)
+	return nil
+}
@@ -0,0 +1,749 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ColumnSizing describes how the width of a table column is computed.
+type ColumnSizing int
+
+// Column sizings.
+const (
+	// ColumnAuto columns are as wide as their widest cell, including
+	// the header.
+	ColumnAuto ColumnSizing = iota
+
+	// ColumnFixed columns are Width cells wide.
+	ColumnFixed
+
+	// ColumnFlex columns share the space left by the other columns,
+	// proportionally to their Width. A Width of 0 means 1.
+	ColumnFlex
+)
+
+// TableColumn describes a column of a Table.
+type TableColumn struct {
+	// Title is the text shown in the header of the column.
+	Title string
+
+	// Sizing and Width describe the width of the column. See ColumnSizing.
+	Sizing ColumnSizing
+	Width  int
+
+	// MinWidth and MaxWidth limit the width of ColumnAuto and ColumnFlex
+	// columns. A MaxWidth of 0 means no limit.
+	MinWidth, MaxWidth int
+
+	// Align is the alignment of the text of the cells of the column.
+	Align Alignment
+
+	// Less, if not nil, reports whether the text a sorts before the text
+	// b. Otherwise, cells are compared as numbers if both of them are
+	// numbers, or as strings if not.
+	Less func(a, b string) bool
+}
+
+// TableCell is a cell of a Table.
+type TableCell struct {
+	// Text is the content of the cell. Text that does not fit in its
+	// column is truncated with an ellipsis.
+	Text string
+
+	// FgColor and BgColor are the colors of the cell. If they are
+	// ColorDefault, the colors of the view are used.
+	FgColor, BgColor Attribute
+}
+
+// TableCells returns a row of cells with the given texts and the default
+// colors.
+func TableCells(texts ...string) []TableCell {
+	row := make([]TableCell, len(texts))
+	for i, t := range texts {
+		row[i] = TableCell{Text: t}
+	}
+	return row
+}
+
+// Table is a widget that shows rows of cells under a fixed header. The row
+// under the cursor is highlighted and can be moved with the arrow keys,
+// PgUp, PgDn, Home, End, the mouse wheel and clicks. Enter or a double click
+// selects it. The left and right arrows change the current column, which is
+// underlined in the header, scrolling the table horizontally if needed. 's'
+// or a click on the header sorts the rows by the column, reversing the
+// order if they were already sorted by it. '<' and '>' shrink and grow the
+// current column, which can also be resized dragging the right edge of its
+// header with the mouse.
+type Table struct {
+	widget
+
+	// Title is the title of the view of the table.
+	Title string
+
+	// Columns are the columns of the table.
+	Columns []TableColumn
+
+	// HeaderFgColor and HeaderBgColor are the colors of the header. If
+	// they are ColorDefault, the header is shown in bold.
+	HeaderFgColor, HeaderBgColor Attribute
+
+	// OnSelect, if not nil, is called when the row under the cursor is
+	// selected with Enter or a double click.
+	OnSelect func(g *Gui, t *Table, row int) error
+
+	// OnChange, if not nil, is called when the row under the cursor
+	// changes.
+	OnChange func(g *Gui, t *Table) error
+
+	rows    [][]TableCell
+	order   []int // indices of the rows in the order they are shown
+	sortCol int   // column used to sort the rows, -1 if unsorted
+	sortRev bool
+	cursor  int // position of the cursor in the shown rows
+	offset  int // first shown row in the view
+	column  int // current column
+	xoff    int // horizontal scroll, in cells
+
+	autoWidths []int // widths of the ColumnAuto columns, nil if unknown
+	starts     []int // start of the columns in the last layout
+	widths     []int // widths of the columns in the last layout
+	resizing   int   // column being resized with the mouse, -1 if none
+
+	lastCursor int // row under the cursor in the last layout
+}
+
+// NewTable returns a new Table with the given name, position and columns.
+func NewTable(name string, x0, y0, x1, y1 int, columns []TableColumn) *Table {
+	return &Table{
+		widget:     widget{name: name, x0: x0, y0: y0, x1: x1, y1: y1},
+		Columns:    columns,
+		sortCol:    -1,
+		resizing:   -1,
+		lastCursor: -1,
+	}
+}
+
+// SetRows replaces the rows of the table. If the table is sorted, the new
+// rows are sorted too. The cursor is moved to the first row.
+func (t *Table) SetRows(rows [][]TableCell) {
+	t.rows = rows
+	t.order = make([]int, len(rows))
+	for i := range t.order {
+		t.order[i] = i
+	}
+	t.cursor, t.offset = 0, 0
+	t.autoWidths = nil
+	t.sort()
+}
+
+// AppendRow adds a row at the end of the table, or in its place if the
+// table is sorted. The cursor stays on the same row.
+func (t *Table) AppendRow(row []TableCell) {
+	cur := t.Cursor()
+	t.rows = append(t.rows, row)
+	t.order = append(t.order, len(t.rows)-1)
+	t.autoWidths = nil
+	t.sort()
+	if cur >= 0 {
+		t.SetCursor(cur)
+	}
+}
+
+// Rows returns the rows of the table, in the order they were added.
+func (t *Table) Rows() [][]TableCell {
+	return t.rows
+}
+
+// Cursor returns the index of the row under the cursor, or -1 if the table
+// is empty.
+func (t *Table) Cursor() int {
+	if t.cursor < 0 || t.cursor >= len(t.order) {
+		return -1
+	}
+	return t.order[t.cursor]
+}
+
+// SetCursor moves the cursor to the row with the given index.
+func (t *Table) SetCursor(row int) error {
+	for i, r := range t.order {
+		if r == row {
+			t.cursor = i
+			return nil
+		}
+	}
+	return errors.New("invalid row")
+}
+
+// CurrentColumn returns the index of the current column.
+func (t *Table) CurrentColumn() int {
+	return t.column
+}
+
+// SetCurrentColumn changes the current column, which is scrolled into view.
+func (t *Table) SetCurrentColumn(col int) error {
+	if col < 0 || col >= len(t.Columns) {
+		return errors.New("invalid column")
+	}
+	t.column = col
+	return nil
+}
+
+// SortBy sorts the rows by the given column, in descending order if desc
+// is true. The sort is stable and the cursor stays on the same row.
+func (t *Table) SortBy(col int, desc bool) error {
+	if col < 0 || col >= len(t.Columns) {
+		return errors.New("invalid column")
+	}
+	cur := t.Cursor()
+	t.sortCol, t.sortRev = col, desc
+	t.sort()
+	if cur >= 0 {
+		t.SetCursor(cur)
+	}
+	return nil
+}
+
+// SortColumn returns the column used to sort the rows, or -1 if they are
+// not sorted, and whether the order is descending.
+func (t *Table) SortColumn() (col int, desc bool) {
+	return t.sortCol, t.sortRev
+}
+
+// SetColumnWidth makes the column a ColumnFixed column with the given
+// width.
+func (t *Table) SetColumnWidth(col, width int) error {
+	if col < 0 || col >= len(t.Columns) {
+		return errors.New("invalid column")
+	}
+	if width < 1 {
+		width = 1
+	}
+	t.Columns[col].Sizing = ColumnFixed
+	t.Columns[col].Width = width
+	return nil
+}
+
+// Layout draws the table, creating its view if needed.
+func (t *Table) Layout(g *Gui) error {
+	v, err := g.SetView(t.name, t.x0, t.y0, t.x1, t.y1)
+	if err != nil {
+		if err != ErrUnknownView {
+			return err
+		}
+		if err := t.bind(v); err != nil {
+			return err
+		}
+	}
+	v.Title = t.Title
+	t.draw(g, v)
+
+	if cur := t.Cursor(); cur != t.lastCursor {
+		t.lastCursor = cur
+		if t.OnChange != nil {
+			return t.OnChange(g, t)
+		}
+	}
+	return nil
+}
+
+// bind sets the keybindings and handlers of the view of the table.
+func (t *Table) bind(v *View) error {
+	if v.SelFgColor == ColorDefault && v.SelBgColor == ColorDefault {
+		v.SelFgColor = v.FgColor | AttrReverse
+	}
+	v.OnMouse = t.onMouse
+
+	bindings := []struct {
+		key     interface{}
+		handler func(*Gui, *View) error
+	}{
+		{KeyArrowUp, t.moveHandler(-1)},
+		{KeyArrowDown, t.moveHandler(1)},
+		{KeyPgup, t.pageHandler(-1)},
+		{KeyPgdn, t.pageHandler(1)},
+		{KeyHome, t.onHome},
+		{KeyEnd, t.onEnd},
+		{KeyArrowLeft, t.columnHandler(-1)},
+		{KeyArrowRight, t.columnHandler(1)},
+		{KeyEnter, t.onEnter},
+		{'s', t.onSort},
+		{'<', t.resizeHandler(-1)},
+		{'>', t.resizeHandler(1)},
+	}
+	for _, b := range bindings {
+		if err := v.SetKeybinding(b.key, ModNone, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sort sorts the rows by the sort column.
+func (t *Table) sort() {
+	if t.sortCol < 0 || t.sortCol >= len(t.Columns) {
+		return
+	}
+	less := t.Columns[t.sortCol].Less
+	if less == nil {
+		less = lessCell
+	}
+	sort.SliceStable(t.order, func(i, j int) bool {
+		a, b := t.cellText(t.order[i], t.sortCol), t.cellText(t.order[j], t.sortCol)
+		if t.sortRev {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+}
+
+// lessCell compares a and b as numbers if both of them are numbers, or as
+// strings if not.
+func lessCell(a, b string) bool {
+	fa, erra := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	fb, errb := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if erra == nil && errb == nil {
+		return fa < fb
+	}
+	return a < b
+}
+
+// cellText returns the text of a cell, which is empty if the row does not
+// have that column.
+func (t *Table) cellText(row, col int) string {
+	if col >= len(t.rows[row]) {
+		return ""
+	}
+	return t.rows[row][col].Text
+}
+
+// columnWidths computes the width of every column for a view of the given
+// width.
+func (t *Table) columnWidths(width int) []int {
+	if len(t.autoWidths) != len(t.Columns) {
+		t.autoWidths = make([]int, len(t.Columns))
+		for i, c := range t.Columns {
+			if c.Sizing != ColumnAuto {
+				continue
+			}
+			w := TextWidth(c.Title) + 1 // room for the sort indicator
+			for _, row := range t.rows {
+				if i < len(row) {
+					if cw := TextWidth(row[i].Text); cw > w {
+						w = cw
+					}
+				}
+			}
+			t.autoWidths[i] = w
+		}
+	}
+
+	widths := make([]int, len(t.Columns))
+	left := width - (len(t.Columns) - 1)
+	var weights float64
+	for i, c := range t.Columns {
+		switch c.Sizing {
+		case ColumnFixed:
+			widths[i] = c.Width
+		case ColumnAuto:
+			widths[i] = c.clamp(t.autoWidths[i])
+		case ColumnFlex:
+			weights += c.weight()
+			continue
+		}
+		left -= widths[i]
+	}
+	for i, c := range t.Columns {
+		if c.Sizing != ColumnFlex {
+			continue
+		}
+		w := 0
+		if left > 0 {
+			w = int(float64(left) * c.weight() / weights)
+			weights -= c.weight()
+			left -= w
+		}
+		widths[i] = c.clamp(w)
+	}
+	return widths
+}
+
+// clamp limits the width of the column to MinWidth and MaxWidth.
+func (c TableColumn) clamp(w int) int {
+	if c.MaxWidth > 0 && w > c.MaxWidth {
+		w = c.MaxWidth
+	}
+	if w < c.MinWidth {
+		w = c.MinWidth
+	}
+	if w < 1 {
+		w = 1
+	}
+	return w
+}
+
+// weight returns the weight of a ColumnFlex column.
+func (c TableColumn) weight() float64 {
+	if c.Width <= 0 {
+		return 1
+	}
+	return float64(c.Width)
+}
+
+// draw writes the header and the shown rows that fit in the view.
+func (t *Table) draw(g *Gui, v *View) {
+	w, h := v.Size()
+	n := len(t.order)
+	rows := h - 1 // the header takes a row
+
+	if t.cursor >= n {
+		t.cursor = n - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	} else if rows > 0 && t.cursor >= t.offset+rows {
+		t.offset = t.cursor - rows + 1
+	}
+	if last := n - rows; t.offset > last {
+		t.offset = last
+	}
+	if t.offset < 0 {
+		t.offset = 0
+	}
+	if t.column >= len(t.Columns) {
+		t.column = len(t.Columns) - 1
+	}
+	if t.column < 0 {
+		t.column = 0
+	}
+
+	t.widths = t.columnWidths(w)
+	t.starts = make([]int, len(t.widths))
+	total := 0
+	for i, cw := range t.widths {
+		if i > 0 {
+			total++
+		}
+		t.starts[i] = total
+		total += cw
+	}
+
+	// keep the current column in view
+	if len(t.Columns) > 0 {
+		start, end := t.starts[t.column], t.starts[t.column]+t.widths[t.column]
+		if end > t.xoff+w {
+			t.xoff = end - w
+		}
+		if start < t.xoff {
+			t.xoff = start
+		}
+	}
+	if last := total - w; t.xoff > last {
+		t.xoff = last
+	}
+	if t.xoff < 0 {
+		t.xoff = 0
+	}
+
+	lines := make([][]cell, 0, h)
+	lines = append(lines, t.scrollLine(t.headerCells(g, v), w))
+	for row := 0; row < rows && t.offset+row < n; row++ {
+		lines = append(lines, t.scrollLine(t.rowCells(g, t.order[t.offset+row]), w))
+	}
+	setLines(v, lines)
+
+	v.Highlight = n > 0
+	v.SetOrigin(0, 0)
+	v.SetCursor(0, 1+t.cursor-t.offset)
+}
+
+// headerCells returns the cells of the header of the table.
+func (t *Table) headerCells(g *Gui, v *View) []cell {
+	fgColor, bgColor := t.HeaderFgColor, t.HeaderBgColor
+	if fgColor == ColorDefault && bgColor == ColorDefault {
+		fgColor = v.FgColor | AttrBold
+	}
+
+	var cells []cell
+	for i, c := range t.Columns {
+		if i > 0 {
+			cells = append(cells, cell{chr: ' ', fgColor: fgColor, bgColor: bgColor})
+		}
+		title := c.Title
+		if i == t.sortCol {
+			title += t.sortIndicator(g)
+		}
+		fg := fgColor
+		if i == t.column {
+			fg |= AttrUnderline
+		}
+		cells = append(cells, alignCells(g, title, t.widths[i], c.Align, fg, bgColor)...)
+	}
+	return cells
+}
+
+// sortIndicator returns the text added to the title of the sort column.
+func (t *Table) sortIndicator(g *Gui) string {
+	switch {
+	case g.ASCII && t.sortRev:
+		return "v"
+	case g.ASCII:
+		return "^"
+	case t.sortRev:
+		return "▼"
+	default:
+		return "▲"
+	}
+}
+
+// rowCells returns the cells of a row of the table.
+func (t *Table) rowCells(g *Gui, row int) []cell {
+	var cells []cell
+	for i, c := range t.Columns {
+		if i > 0 {
+			cells = append(cells, cell{chr: ' '})
+		}
+		var tc TableCell
+		if i < len(t.rows[row]) {
+			tc = t.rows[row][i]
+		}
+		cells = append(cells, alignCells(g, tc.Text, t.widths[i], c.Align, tc.FgColor, tc.BgColor)...)
+	}
+	return cells
+}
+
+// scrollLine returns the part of the line that is shown with the current
+// horizontal scroll, filled with spaces up to the given width.
+func (t *Table) scrollLine(cells []cell, width int) []cell {
+	line := make([]cell, 0, width)
+	if t.xoff < len(cells) {
+		end := t.xoff + width
+		if end > len(cells) {
+			end = len(cells)
+		}
+		line = append(line, cells[t.xoff:end]...)
+	}
+	if n := len(line); n > 0 && runeWidth(line[n-1].chr) > 1 {
+		// the second half of the rune does not fit
+		line[n-1].chr = ' '
+	}
+	for len(line) < width {
+		line = append(line, cell{chr: ' '})
+	}
+	return line
+}
+
+// alignCells returns the cells of the text aligned in the given width. If
+// the text is too long, it is truncated with an ellipsis. Runes that take
+// two cells on the terminal are followed by a filler cell, which is not
+// drawn by termbox, so the columns stay aligned.
+func alignCells(g *Gui, text string, width int, align Alignment, fgColor, bgColor Attribute) []cell {
+	if tw := TextWidth(text); tw > width {
+		ellipsis := g.ellipsis()
+		ew := TextWidth(ellipsis)
+		if width <= ew {
+			ellipsis, ew = "", 0
+		}
+		var b strings.Builder
+		w := 0
+		for _, r := range text {
+			rw := runeWidth(r)
+			if w+rw > width-ew {
+				break
+			}
+			b.WriteRune(r)
+			w += rw
+		}
+		text = b.String() + ellipsis
+	}
+
+	cells := make([]cell, 0, width)
+	for _, r := range text {
+		if r < ' ' {
+			r = ' '
+		}
+		cells = append(cells, cell{chr: r, fgColor: fgColor, bgColor: bgColor})
+		if runeWidth(r) > 1 {
+			cells = append(cells, cell{chr: ' ', fgColor: fgColor, bgColor: bgColor})
+		}
+	}
+
+	pad := width - len(cells)
+	var before int
+	switch align {
+	case AlignCenter:
+		before = pad / 2
+	case AlignRight:
+		before = pad
+	}
+	space := cell{chr: ' ', fgColor: fgColor, bgColor: bgColor}
+	aligned := make([]cell, 0, width)
+	for i := 0; i < before; i++ {
+		aligned = append(aligned, space)
+	}
+	aligned = append(aligned, cells...)
+	for len(aligned) < width {
+		aligned = append(aligned, space)
+	}
+	return aligned
+}
+
+// move moves the cursor by the given number of rows.
+func (t *Table) move(delta int) {
+	t.cursor += delta
+	if n := len(t.order); t.cursor >= n {
+		t.cursor = n - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+}
+
+// moveHandler returns a handler that moves the cursor by delta rows.
+func (t *Table) moveHandler(delta int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		t.move(delta)
+		return nil
+	}
+}
+
+// pageHandler returns a handler that moves the cursor by the given number
+// of pages.
+func (t *Table) pageHandler(pages int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		_, h := v.Size()
+		t.move(pages * (h - 1))
+		return nil
+	}
+}
+
+// onHome moves the cursor to the first row.
+func (t *Table) onHome(g *Gui, v *View) error {
+	t.cursor = 0
+	return nil
+}
+
+// onEnd moves the cursor to the last row.
+func (t *Table) onEnd(g *Gui, v *View) error {
+	t.move(len(t.order))
+	return nil
+}
+
+// columnHandler returns a handler that changes the current column by delta
+// columns.
+func (t *Table) columnHandler(delta int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		t.SetCurrentColumn(t.column + delta)
+		return nil
+	}
+}
+
+// resizeHandler returns a handler that changes the width of the current
+// column by delta cells.
+func (t *Table) resizeHandler(delta int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		if t.column >= len(t.widths) {
+			return nil
+		}
+		return t.SetColumnWidth(t.column, t.widths[t.column]+delta)
+	}
+}
+
+// onEnter calls the OnSelect handler with the row under the cursor.
+func (t *Table) onEnter(g *Gui, v *View) error {
+	if row := t.Cursor(); row >= 0 && t.OnSelect != nil {
+		return t.OnSelect(g, t, row)
+	}
+	return nil
+}
+
+// onSort sorts the rows by the current column.
+func (t *Table) onSort(g *Gui, v *View) error {
+	return t.toggleSort(t.column)
+}
+
+// toggleSort sorts the rows by the given column, reversing the order if
+// they were already sorted by it.
+func (t *Table) toggleSort(col int) error {
+	desc := false
+	if col == t.sortCol {
+		desc = !t.sortRev
+	}
+	return t.SortBy(col, desc)
+}
+
+// onMouse manages the mouse events on the view of the table.
+func (t *Table) onMouse(g *Gui, v *View, ev *MouseEvent) error {
+	if t.resizing >= 0 {
+		switch ev.Action {
+		case MouseActionDrag:
+			return t.SetColumnWidth(t.resizing, ev.ViewX+t.xoff-t.starts[t.resizing])
+		case MouseActionRelease:
+			t.resizing = -1
+		}
+		return nil
+	}
+	if ev.Action != MouseActionPress {
+		return nil
+	}
+
+	w, h := v.Size()
+	switch ev.Button {
+	case MouseWheelUp, MouseWheelDown:
+		delta := 1
+		if ev.Button == MouseWheelUp {
+			delta = -1
+		}
+		rows := h - 1
+		if last := len(t.order) - rows; t.offset+delta >= 0 && t.offset+delta <= last {
+			t.offset += delta
+		}
+		if t.cursor < t.offset {
+			t.cursor = t.offset
+		} else if t.cursor >= t.offset+rows {
+			t.cursor = t.offset + rows - 1
+		}
+	case MouseLeft:
+		if ev.ViewX < 0 || ev.ViewX >= w || ev.ViewY < 0 || ev.ViewY >= h {
+			return nil
+		}
+		x := ev.ViewX + t.xoff
+		if ev.ViewY == 0 {
+			for i, start := range t.starts {
+				end := start + t.widths[i]
+				switch {
+				case x == end:
+					t.resizing = i
+					return g.CaptureMouse(t.name)
+				case x >= start && x < end:
+					t.column = i
+					return t.toggleSort(i)
+				}
+			}
+			return nil
+		}
+
+		pos := t.offset + ev.ViewY - 1
+		if pos >= len(t.order) {
+			return nil
+		}
+		t.cursor = pos
+		for i, start := range t.starts {
+			if x >= start && x < start+t.widths[i] {
+				t.column = i
+			}
+		}
+		if ev.Clicks == 2 {
+			return t.onEnter(g, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import "strings"
+
+// TextInput is a widget that allows to edit a single line of text. The
+// text scrolls horizontally when it does not fit. Enter submits the text
+// and Esc cancels the edition, if InputEsc is enabled in the GUI. Up and
+// Down recall the previous and next entries of the history. Besides the
+// usual keys to edit, Ctrl-A and Ctrl-E move to the beginning and the end
+// of the text, and Ctrl-U and Ctrl-K delete the text before and after the
+// cursor. The cursor is only shown if Cursor is enabled in the GUI.
+type TextInput struct {
+	widget
+
+	// Title is the title of the view of the input, which can be used as
+	// its label.
+	Title string
+
+	// Placeholder is the text shown while the input is empty.
+	Placeholder string
+
+	// PlaceholderColor is the color of the placeholder. If it is
+	// ColorDefault, ColorBlack|AttrBold is used, which is shown as gray
+	// by most terminals.
+	PlaceholderColor Attribute
+
+	// Mask, if not 0, is shown instead of the runes of the text, using the
+	// Mask of the view.
+	Mask rune
+
+	// MaxLength is the maximum number of runes of the text. A value of 0
+	// means no limit.
+	MaxLength int
+
+	// Accept, if not nil, is called for every typed or pasted rune. The
+	// rune is discarded if it returns false.
+	Accept func(r rune) bool
+
+	// Validate, if not nil, is called every time the text changes and
+	// before submitting it. If it returns an error, the error is shown in
+	// the footer of the view and the text cannot be submitted.
+	Validate func(text string) error
+
+	// History are the entries recalled with Up and Down, the most recent
+	// one last. Submitted texts are added to it.
+	History []string
+
+	// If ClearOnSubmit is true, the text is cleared after submitting it.
+	ClearOnSubmit bool
+
+	// OnSubmit, if not nil, is called when the text is submitted with
+	// Enter and it is valid.
+	OnSubmit func(g *Gui, t *TextInput, text string) error
+
+	// OnCancel, if not nil, is called when Esc is pressed.
+	OnCancel func(g *Gui, t *TextInput) error
+
+	// OnChange, if not nil, is called when the text changes.
+	OnChange func(g *Gui, t *TextInput) error
+
+	text    []rune
+	pos     int    // position of the cursor in text
+	histPos int    // position in History, len(History) for the new text
+	draft   []rune // new text, saved while browsing the history
+	err     error  // result of the last validation
+	touched bool   // marks if the text has been edited or submitted
+
+	lastText string // text in the last layout
+}
+
+// NewTextInput returns a new TextInput with the given name and position.
+// With a frame, the input needs 3 rows, so y1 is usually y0+2.
+func NewTextInput(name string, x0, y0, x1, y1 int) *TextInput {
+	return &TextInput{widget: widget{name: name, x0: x0, y0: y0, x1: x1, y1: y1}}
+}
+
+// Text returns the text of the input.
+func (t *TextInput) Text() string {
+	return string(t.text)
+}
+
+// SetText replaces the text of the input and moves the cursor to its end.
+func (t *TextInput) SetText(s string) {
+	t.text = []rune(s)
+	t.pos = len(t.text)
+	t.histPos = len(t.History)
+	t.validate()
+}
+
+// Err returns the error returned by Validate for the current text.
+func (t *TextInput) Err() error {
+	return t.err
+}
+
+// Layout draws the input, creating its view if needed.
+func (t *TextInput) Layout(g *Gui) error {
+	v, err := g.SetView(t.name, t.x0, t.y0, t.x1, t.y1)
+	if err != nil {
+		if err != ErrUnknownView {
+			return err
+		}
+		if err := t.bind(v); err != nil {
+			return err
+		}
+	}
+	v.Title = t.Title
+	v.Footer = ""
+	if t.err != nil && t.touched {
+		v.Footer = t.err.Error()
+	}
+	t.draw(v)
+
+	if s := string(t.text); s != t.lastText {
+		t.lastText = s
+		if t.OnChange != nil {
+			return t.OnChange(g, t)
+		}
+	}
+	return nil
+}
+
+// bind sets the keybindings and handlers of the view of the input.
+func (t *TextInput) bind(v *View) error {
+	v.Editable = true
+	v.Wrap = false
+	v.Editor = inputEditor{t}
+	v.OnMouse = t.onMouse
+	t.histPos = len(t.History)
+	t.validate()
+
+	if err := v.SetKeybinding(KeyEnter, ModNone, t.onEnter); err != nil {
+		return err
+	}
+	return v.SetKeybinding(KeyEsc, ModNone, t.onEsc)
+}
+
+// draw writes the text, or the placeholder if it is empty, to the view and
+// moves its cursor.
+func (t *TextInput) draw(v *View) {
+	var line []cell
+	x := 0
+	if len(t.text) == 0 {
+		v.Mask = 0
+		fgColor := t.PlaceholderColor
+		if fgColor == ColorDefault {
+			fgColor = ColorBlack | AttrBold
+		}
+		for _, r := range t.Placeholder {
+			line = append(line, cell{chr: r, fgColor: fgColor})
+		}
+	} else {
+		v.Mask = t.Mask
+		for i, r := range t.text {
+			if i == t.pos {
+				x = len(line)
+			}
+			line = append(line, cell{chr: r})
+			if t.Mask == 0 && runeWidth(r) > 1 {
+				// filler cell, not drawn by termbox
+				line = append(line, cell{chr: ' '})
+			}
+		}
+		if t.pos == len(t.text) {
+			x = len(line)
+		}
+	}
+
+	setLines(v, [][]cell{line})
+	v.setCursorPosition(x, 0)
+}
+
+// validate checks the text with Validate.
+func (t *TextInput) validate() {
+	t.err = nil
+	if t.Validate != nil {
+		t.err = t.Validate(string(t.text))
+	}
+}
+
+// insert inserts the runes at the cursor position, discarding those that
+// are not accepted or do not fit.
+func (t *TextInput) insert(rs ...rune) {
+	for _, r := range rs {
+		if r < ' ' || (t.Accept != nil && !t.Accept(r)) {
+			continue
+		}
+		if t.MaxLength > 0 && len(t.text) >= t.MaxLength {
+			break
+		}
+		t.text = append(t.text, 0)
+		copy(t.text[t.pos+1:], t.text[t.pos:])
+		t.text[t.pos] = r
+		t.pos++
+	}
+	t.edited()
+}
+
+// delete deletes the runes between the positions from and to.
+func (t *TextInput) delete(from, to int) {
+	if from < 0 {
+		from = 0
+	}
+	if to > len(t.text) {
+		to = len(t.text)
+	}
+	if from >= to {
+		return
+	}
+	t.text = append(t.text[:from], t.text[to:]...)
+	t.pos = from
+	t.edited()
+}
+
+// edited is called after editing the text.
+func (t *TextInput) edited() {
+	t.touched = true
+	t.histPos = len(t.History)
+	t.validate()
+}
+
+// recall replaces the text with the entry of the history at the given
+// position, or with the new text if it is past the last entry.
+func (t *TextInput) recall(pos int) {
+	if pos < 0 || pos > len(t.History) || pos == t.histPos {
+		return
+	}
+	if t.histPos == len(t.History) {
+		t.draft = append([]rune(nil), t.text...)
+	}
+	t.histPos = pos
+	if pos == len(t.History) {
+		t.text = t.draft
+	} else {
+		t.text = []rune(t.History[pos])
+	}
+	t.pos = len(t.text)
+	t.validate()
+}
+
+// onEnter submits the text if it is valid.
+func (t *TextInput) onEnter(g *Gui, v *View) error {
+	t.touched = true
+	t.validate()
+	if t.err != nil {
+		return nil
+	}
+
+	text := string(t.text)
+	if text != "" && (len(t.History) == 0 || t.History[len(t.History)-1] != text) {
+		t.History = append(t.History, text)
+	}
+	t.histPos = len(t.History)
+	if t.ClearOnSubmit {
+		t.text, t.pos = nil, 0
+		t.touched = false
+		t.validate()
+	}
+	if t.OnSubmit != nil {
+		return t.OnSubmit(g, t, text)
+	}
+	return nil
+}
+
+// onEsc calls the OnCancel handler.
+func (t *TextInput) onEsc(g *Gui, v *View) error {
+	if t.OnCancel != nil {
+		return t.OnCancel(g, t)
+	}
+	return nil
+}
+
+// onMouse moves the cursor to the clicked rune.
+func (t *TextInput) onMouse(g *Gui, v *View, ev *MouseEvent) error {
+	if ev.Action != MouseActionPress || ev.Button != MouseLeft {
+		return nil
+	}
+	x := ev.ViewX + v.ox
+	t.pos = len(t.text)
+	for i, r := range t.text {
+		w := 1
+		if t.Mask == 0 {
+			w = runeWidth(r)
+		}
+		if x < w {
+			t.pos = i
+			break
+		}
+		x -= w
+	}
+	return nil
+}
+
+// inputEditor is the Editor of the view of a TextInput.
+type inputEditor struct {
+	t *TextInput
+}
+
+// Edit edits the text of the input.
+func (e inputEditor) Edit(v *View, key Key, ch rune, mod Modifier) {
+	t := e.t
+	switch {
+	case ch != 0 && mod == 0:
+		t.insert(ch)
+	case key == KeySpace:
+		t.insert(' ')
+	case key == KeyBackspace || key == KeyBackspace2:
+		t.delete(t.pos-1, t.pos)
+	case key == KeyDelete || key == KeyCtrlD:
+		t.delete(t.pos, t.pos+1)
+	case key == KeyCtrlU:
+		t.delete(0, t.pos)
+	case key == KeyCtrlK:
+		t.delete(t.pos, len(t.text))
+	case key == KeyArrowLeft && t.pos > 0:
+		t.pos--
+	case key == KeyArrowRight && t.pos < len(t.text):
+		t.pos++
+	case key == KeyHome || key == KeyCtrlA:
+		t.pos = 0
+	case key == KeyEnd || key == KeyCtrlE:
+		t.pos = len(t.text)
+	case key == KeyArrowUp:
+		t.recall(t.histPos - 1)
+	case key == KeyArrowDown:
+		t.recall(t.histPos + 1)
+	}
+}
+
+// Paste inserts the pasted text, replacing new lines and tabs with spaces.
+func (e inputEditor) Paste(v *View, text string) {
+	text = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ").Replace(text)
+	e.t.insert([]rune(text)...)
+}
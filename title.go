@@ -0,0 +1,163 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+// Alignment represents the horizontal alignment of a text.
+type Alignment int
+
+// Alignments.
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+)
+
+// TitleSegment is a piece of text drawn on the top or bottom edge of the
+// frame of a view, in addition to its Title and Footer. Segments with the
+// same alignment are drawn next to each other, separated by a space.
+type TitleSegment struct {
+	// Text is the text of the segment.
+	Text string
+
+	// Align is the position of the segment on the edge.
+	Align Alignment
+
+	// FgColor and BgColor are the colors of the segment. If they are not
+	// set, the title colors of the view are used.
+	FgColor, BgColor Attribute
+}
+
+// drawTitle draws the title and the title segments of the view on the top
+// edge of its frame.
+func (g *Gui) drawTitle(v *View) error {
+	segs := v.TitleSegments
+	if v.Title != "" {
+		title := TitleSegment{Text: v.Title, Align: v.TitleAlign}
+		segs = append([]TitleSegment{title}, segs...)
+	}
+	return g.drawTitleSegments(v, v.y0, segs)
+}
+
+// drawFooter draws the footer and the footer segments of the view on the
+// bottom edge of its frame.
+func (g *Gui) drawFooter(v *View) error {
+	segs := v.FooterSegments
+	if v.Footer != "" {
+		footer := TitleSegment{Text: v.Footer, Align: v.FooterAlign}
+		segs = append([]TitleSegment{footer}, segs...)
+	}
+	return g.drawTitleSegments(v, v.y1, segs)
+}
+
+// drawTitleSegments draws the given segments on the edge of the frame at y.
+// The left segments have precedence over the right ones, which have
+// precedence over the centered ones. Text that does not fit is truncated
+// with an ellipsis.
+func (g *Gui) drawTitleSegments(v *View, y int, segs []TitleSegment) error {
+	if len(segs) == 0 || y < 0 || y >= g.maxY {
+		return nil
+	}
+
+	x0, width := v.x0+2, v.x1-v.x0-3
+	if width <= 0 {
+		return nil
+	}
+
+	left := g.titleCells(v, segs, AlignLeft)
+	right := g.titleCells(v, segs, AlignRight)
+	center := g.titleCells(v, segs, AlignCenter)
+
+	left = g.truncateCells(left, width)
+	start, end := x0+len(left), x0+width
+	if len(left) > 0 {
+		start++
+	}
+
+	right = g.truncateCells(right, end-start)
+	end -= len(right)
+	if len(right) > 0 {
+		end--
+	}
+
+	center = g.truncateCells(center, end-start)
+	cx := x0 + (width-len(center))/2
+	if cx < start {
+		cx = start
+	} else if cx+len(center) > end {
+		cx = end - len(center)
+	}
+
+	if err := g.drawCells(x0, y, left); err != nil {
+		return err
+	}
+	if err := g.drawCells(cx, y, center); err != nil {
+		return err
+	}
+	return g.drawCells(x0+width-len(right), y, right)
+}
+
+// titleCells returns the cells of the segments with the given alignment.
+func (g *Gui) titleCells(v *View, segs []TitleSegment, align Alignment) []cell {
+	var cells []cell
+	for _, seg := range segs {
+		if seg.Align != align || seg.Text == "" {
+			continue
+		}
+		fgColor, bgColor := seg.FgColor, seg.BgColor
+		if fgColor == ColorDefault {
+			fgColor = v.TitleFgColor
+		}
+		if bgColor == ColorDefault {
+			bgColor = v.TitleBgColor
+		}
+		if len(cells) > 0 {
+			cells = append(cells, cell{chr: ' ', fgColor: v.TitleFgColor, bgColor: v.TitleBgColor})
+		}
+		for _, ch := range seg.Text {
+			cells = append(cells, cell{chr: ch, fgColor: fgColor, bgColor: bgColor})
+		}
+	}
+	return cells
+}
+
+// truncateCells truncates the cells to the given length, replacing the
+// last ones with an ellipsis.
+func (g *Gui) truncateCells(cells []cell, n int) []cell {
+	if n <= 0 {
+		return nil
+	}
+	if len(cells) <= n {
+		return cells
+	}
+
+	ellipsis := g.ellipsis()
+	m := n - len([]rune(ellipsis))
+	if m <= 0 {
+		return cells[:n]
+	}
+
+	c := cells[m-1]
+	cells = append([]cell(nil), cells[:m]...)
+	for _, ch := range ellipsis {
+		cells = append(cells, cell{chr: ch, fgColor: c.fgColor, bgColor: c.bgColor})
+	}
+	return cells
+}
+
+// drawCells draws the cells on the frame, starting at the given position.
+func (g *Gui) drawCells(x, y int, cells []cell) error {
+	for i, c := range cells {
+		if x+i < 0 {
+			continue
+		} else if x+i >= g.maxX {
+			break
+		}
+		if err := g.SetRune(x+i, y, c.chr, c.fgColor, c.bgColor); err != nil {
+			return err
+		}
+		g.clearFrameLines(x+i, y, x+i, y)
+	}
+	return nil
+}
@@ -0,0 +1,468 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"errors"
+	"strings"
+)
+
+// TreeNode is a node of a Tree.
+type TreeNode struct {
+	// Text is the text shown for the node. It can contain escape
+	// sequences to set its colors.
+	Text string
+
+	// Data allows to attach any value to the node.
+	Data interface{}
+
+	// Children are the children of the node.
+	Children []*TreeNode
+
+	// Expanded is true if the children of the node are shown.
+	Expanded bool
+
+	// If Leaf is true, the node cannot be expanded. It allows to tell the
+	// nodes without children apart from the nodes whose children have not
+	// been loaded yet (see Tree.LoadChildren).
+	Leaf bool
+
+	parent *TreeNode
+	loaded bool // marks if LoadChildren has been called
+}
+
+// AddChild adds c as the last child of the node and returns it.
+func (n *TreeNode) AddChild(c *TreeNode) *TreeNode {
+	c.parent = n
+	n.Children = append(n.Children, c)
+	return c
+}
+
+// Parent returns the parent of the node, or nil if it is the root.
+func (n *TreeNode) Parent() *TreeNode {
+	return n.parent
+}
+
+// treeLine is a line of a Tree, which shows a node.
+type treeLine struct {
+	node  *TreeNode
+	guide string // indentation guides drawn before the expander
+}
+
+// Tree is a widget that shows a hierarchy of nodes, which can be expanded
+// and collapsed. The current node is highlighted and can be moved with the
+// arrow keys, PgUp, PgDn, Home, End and clicks. The right arrow expands the
+// current node or moves to its first child, the left arrow collapses it or
+// moves to its parent, and the space key or a click on the expander toggles
+// it. Enter or a double click selects it. The tree is written to the buffer
+// of its view, so it can be scrolled like any other view, including its
+// scrollbar if VScrollbar is set.
+type Tree struct {
+	widget
+
+	// Title is the title of the view of the tree.
+	Title string
+
+	// Root is the root node of the tree.
+	Root *TreeNode
+
+	// If HideRoot is true, the children of the root are shown as the top
+	// level nodes.
+	HideRoot bool
+
+	// LoadChildren, if not nil, is called the first time a node without
+	// children is expanded, unless it is a Leaf. The returned nodes are
+	// set as its children.
+	LoadChildren func(n *TreeNode) ([]*TreeNode, error)
+
+	// OnSelect, if not nil, is called when the current node is selected
+	// with Enter or a double click.
+	OnSelect func(g *Gui, t *Tree, n *TreeNode) error
+
+	// OnChange, if not nil, is called when the current node changes.
+	OnChange func(g *Gui, t *Tree) error
+
+	lines       []treeLine // shown nodes
+	current     *TreeNode
+	follow      bool // marks if the view must be scrolled to the current node
+	lastCurrent *TreeNode
+}
+
+// NewTree returns a new Tree with the given name, position and root node.
+// The root node is expanded. If it is nil, Layout returns an error.
+func NewTree(name string, x0, y0, x1, y1 int, root *TreeNode) *Tree {
+	if root != nil {
+		root.Expanded = true
+	}
+	return &Tree{
+		widget:  widget{name: name, x0: x0, y0: y0, x1: x1, y1: y1},
+		Root:    root,
+		current: root,
+	}
+}
+
+// Current returns the current node.
+func (t *Tree) Current() *TreeNode {
+	return t.current
+}
+
+// SetCurrent makes n the current node, expanding its ancestors so it is
+// shown.
+func (t *Tree) SetCurrent(n *TreeNode) error {
+	for p := n.parent; p != nil; p = p.parent {
+		if err := t.Expand(p); err != nil {
+			return err
+		}
+	}
+	t.current, t.follow = n, true
+	return nil
+}
+
+// Expandable returns if the node has children or they can be loaded.
+func (t *Tree) Expandable(n *TreeNode) bool {
+	if len(n.Children) > 0 {
+		return true
+	}
+	return !n.Leaf && !n.loaded && t.LoadChildren != nil
+}
+
+// Expand shows the children of the node, loading them if needed.
+func (t *Tree) Expand(n *TreeNode) error {
+	if len(n.Children) == 0 && !n.Leaf && !n.loaded && t.LoadChildren != nil {
+		children, err := t.LoadChildren(n)
+		if err != nil {
+			return err
+		}
+		n.loaded = true
+		for _, c := range children {
+			n.AddChild(c)
+		}
+	}
+	n.Expanded = true
+	return nil
+}
+
+// Collapse hides the children of the node. If the current node is one of
+// them, its ancestor n becomes the current node.
+func (t *Tree) Collapse(n *TreeNode) {
+	n.Expanded = false
+	for p := t.current.parent; p != nil; p = p.parent {
+		if p == n {
+			t.current, t.follow = n, true
+			return
+		}
+	}
+}
+
+// Toggle expands the node if it is collapsed, or collapses it if not.
+func (t *Tree) Toggle(n *TreeNode) error {
+	if n.Expanded {
+		t.Collapse(n)
+		return nil
+	}
+	return t.Expand(n)
+}
+
+// Layout draws the tree, creating its view if needed.
+func (t *Tree) Layout(g *Gui) error {
+	if t.Root == nil {
+		return errors.New("tree without root")
+	}
+	v, err := g.SetView(t.name, t.x0, t.y0, t.x1, t.y1)
+	if err != nil {
+		if err != ErrUnknownView {
+			return err
+		}
+		if err := t.bind(v); err != nil {
+			return err
+		}
+	}
+	v.Title = t.Title
+	t.draw(g, v)
+
+	if t.current != t.lastCurrent {
+		t.lastCurrent = t.current
+		if t.OnChange != nil {
+			return t.OnChange(g, t)
+		}
+	}
+	return nil
+}
+
+// bind sets the keybindings and handlers of the view of the tree.
+func (t *Tree) bind(v *View) error {
+	v.Highlight = true
+	v.Wrap = false
+	if v.SelFgColor == ColorDefault && v.SelBgColor == ColorDefault {
+		v.SelFgColor = v.FgColor | AttrReverse
+	}
+	v.OnMouse = t.onMouse
+
+	bindings := []struct {
+		key     Key
+		handler func(*Gui, *View) error
+	}{
+		{KeyArrowUp, t.moveHandler(-1)},
+		{KeyArrowDown, t.moveHandler(1)},
+		{KeyPgup, t.pageHandler(-1)},
+		{KeyPgdn, t.pageHandler(1)},
+		{KeyHome, t.onHome},
+		{KeyEnd, t.onEnd},
+		{KeyArrowRight, t.onRight},
+		{KeyArrowLeft, t.onLeft},
+		{KeySpace, t.onSpace},
+		{KeyEnter, t.onEnter},
+		{MouseWheelUp, t.wheelHandler(-1)},
+		{MouseWheelDown, t.wheelHandler(1)},
+	}
+	for _, b := range bindings {
+		if err := v.SetKeybinding(b.key, ModNone, b.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// draw writes the shown nodes to the view and moves its cursor to the
+// current node.
+func (t *Tree) draw(g *Gui, v *View) {
+	t.lines = t.lines[:0]
+	t.Root.parent = nil
+	if t.HideRoot {
+		t.addChildren(g, t.Root, "")
+	} else {
+		t.lines = append(t.lines, treeLine{node: t.Root})
+		if t.Root.Expanded {
+			t.addChildren(g, t.Root, "")
+		}
+	}
+
+	var b strings.Builder
+	for i, l := range t.lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(l.guide)
+		b.WriteString(t.expander(g, l))
+		b.WriteString(l.node.Text)
+	}
+	writeText(v, b.String())
+
+	cur := t.index(t.current)
+	if cur < 0 {
+		// the current node is no longer shown, use its closest shown
+		// ancestor
+		for p := t.current; p != nil && cur < 0; p = p.parent {
+			cur = t.index(p)
+		}
+		if cur < 0 {
+			cur = 0
+		}
+		t.follow = true
+	}
+
+	_, h := v.Size()
+	v.scrollTo(v.oy)
+	if t.follow {
+		if cur < v.oy {
+			v.scrollTo(cur)
+		} else if h > 0 && cur >= v.oy+h {
+			v.scrollTo(cur - h + 1)
+		}
+		t.follow = false
+	} else if h > 0 {
+		// the view has been scrolled, keep the current node in view
+		if cur < v.oy {
+			cur = v.oy
+		} else if cur >= v.oy+h {
+			cur = v.oy + h - 1
+		}
+	}
+	if cur >= len(t.lines) {
+		cur = len(t.lines) - 1
+	}
+	if cur < 0 {
+		v.cx, v.cy = 0, 0
+		return
+	}
+	t.current = t.lines[cur].node
+	v.cx, v.cy = 0, cur-v.oy
+}
+
+// addChildren adds the lines of the children of the node, which are
+// indented with the given guide.
+func (t *Tree) addChildren(g *Gui, n *TreeNode, guide string) {
+	branch, last, pipe := "├─", "└─", "│ "
+	if g.ASCII {
+		branch, last, pipe = "|-", "`-", "| "
+	}
+	top := n == t.Root && t.HideRoot
+
+	for i, c := range n.Children {
+		c.parent = n
+		cg, next := guide, guide
+		if !top {
+			if i == len(n.Children)-1 {
+				cg += last
+				next += "  "
+			} else {
+				cg += branch
+				next += pipe
+			}
+		}
+		t.lines = append(t.lines, treeLine{node: c, guide: cg})
+		if c.Expanded {
+			t.addChildren(g, c, next)
+		}
+	}
+}
+
+// expander returns the text shown between the guide and the text of the
+// node of the line.
+func (t *Tree) expander(g *Gui, l treeLine) string {
+	expanded, collapsed, leaf := "▾ ", "▸ ", "─ "
+	if g.ASCII {
+		expanded, collapsed, leaf = "- ", "+ ", "- "
+	}
+	switch {
+	case t.Expandable(l.node) && l.node.Expanded:
+		return expanded
+	case t.Expandable(l.node):
+		return collapsed
+	case l.guide == "":
+		return "  "
+	default:
+		return leaf
+	}
+}
+
+// index returns the line of the node, or -1 if it is not shown.
+func (t *Tree) index(n *TreeNode) int {
+	for i, l := range t.lines {
+		if l.node == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// move moves the current node by the given number of lines.
+func (t *Tree) move(delta int) {
+	if len(t.lines) == 0 {
+		return
+	}
+	i := t.index(t.current) + delta
+	if i >= len(t.lines) {
+		i = len(t.lines) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	t.current, t.follow = t.lines[i].node, true
+}
+
+// moveHandler returns a handler that moves the current node by delta
+// lines.
+func (t *Tree) moveHandler(delta int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		t.move(delta)
+		return nil
+	}
+}
+
+// pageHandler returns a handler that moves the current node by the given
+// number of pages.
+func (t *Tree) pageHandler(pages int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		_, h := v.Size()
+		t.move(pages * h)
+		return nil
+	}
+}
+
+// wheelHandler returns a handler that scrolls the view by delta lines.
+func (t *Tree) wheelHandler(delta int) func(*Gui, *View) error {
+	return func(g *Gui, v *View) error {
+		v.ScrollDown(delta)
+		return nil
+	}
+}
+
+// onHome makes the first node the current one.
+func (t *Tree) onHome(g *Gui, v *View) error {
+	t.move(-len(t.lines))
+	return nil
+}
+
+// onEnd makes the last node the current one.
+func (t *Tree) onEnd(g *Gui, v *View) error {
+	t.move(len(t.lines))
+	return nil
+}
+
+// onRight expands the current node or, if it is already expanded, moves
+// to its first child.
+func (t *Tree) onRight(g *Gui, v *View) error {
+	n := t.current
+	if !t.Expandable(n) {
+		return nil
+	}
+	if !n.Expanded {
+		return t.Expand(n)
+	}
+	if len(n.Children) > 0 {
+		t.current, t.follow = n.Children[0], true
+	}
+	return nil
+}
+
+// onLeft collapses the current node or, if it is already collapsed, moves
+// to its parent.
+func (t *Tree) onLeft(g *Gui, v *View) error {
+	n := t.current
+	if n.Expanded && t.Expandable(n) {
+		t.Collapse(n)
+		return nil
+	}
+	if p := n.parent; p != nil && (p != t.Root || !t.HideRoot) {
+		t.current, t.follow = p, true
+	}
+	return nil
+}
+
+// onSpace toggles the current node.
+func (t *Tree) onSpace(g *Gui, v *View) error {
+	return t.Toggle(t.current)
+}
+
+// onEnter calls the OnSelect handler with the current node.
+func (t *Tree) onEnter(g *Gui, v *View) error {
+	if t.OnSelect != nil {
+		return t.OnSelect(g, t, t.current)
+	}
+	return nil
+}
+
+// onMouse manages the clicks on the view of the tree.
+func (t *Tree) onMouse(g *Gui, v *View, ev *MouseEvent) error {
+	if ev.Action != MouseActionPress || ev.Button != MouseLeft {
+		return nil
+	}
+	i := v.oy + ev.ViewY
+	if ev.ViewY < 0 || i >= len(t.lines) {
+		return nil
+	}
+	l := t.lines[i]
+	t.current = l.node
+
+	x := ev.ViewX + v.ox - len([]rune(l.guide))
+	if x >= 0 && x < 2 && t.Expandable(l.node) {
+		return t.Toggle(l.node)
+	}
+	if ev.Clicks == 2 {
+		return t.onEnter(g, v)
+	}
+	return nil
+}
@@ -34,9 +34,15 @@ type View struct {
 	readOffset     int
 	readCache      string
 
+	seq       int        // creation order, used by the focus ring
 	tainted   bool       // marks if the viewBuffer must be updated
+	changed   bool       // marks if the content changed since the last flush
 	viewLines []viewLine // internal representation of the view's buffer
 
+	keybindings   []*keybinding // keybindings owned by the view
+	sized         bool          // marks if width and height are known
+	width, height int           // size of the view in the last flush
+
 	ei *escapeInterpreter // used to decode ESC sequences on Write
 
 	// BgColor and FgColor allow to configure the background and foreground
@@ -71,9 +77,72 @@ type View struct {
 	// for the line under the cursor position.
 	Highlight bool
 
-	// If Frame is true, a border will be drawn around the view.
+	// If Frame is true, a border will be drawn around the view. The borders
+	// shared by adjacent views are joined with the proper junctions.
 	Frame bool
 
+	// FrameStyle allows to configure the runes of the frame. If it is not
+	// set, the FrameStyle of the Gui is used. The sides it hides are also
+	// hidden when the frame is drawn with SelFrameStyle or ASCII runes.
+	FrameStyle FrameStyle
+
+	// Padding is the space left between the frame and the content of the
+	// view. It is not part of the view's Size.
+	Padding Padding
+
+	// VScrollbar and HScrollbar enable the vertical and horizontal
+	// scrollbars of the view, which are shown when its content does not
+	// fit. They can be used with the mouse. The horizontal scrollbar is
+	// ignored if Wrap is true.
+	VScrollbar, HScrollbar bool
+
+	// If ScrollbarInside is true, the scrollbars are drawn inside the view,
+	// instead of on its frame. Views without frame always draw them inside.
+	ScrollbarInside bool
+
+	// Layer is the stacking level of the view. Views are drawn ordered by
+	// Layer and, inside the same layer, by ZIndex. Views with the same
+	// Layer and ZIndex are stacked in creation order, which can be changed
+	// with SetViewOnTop and SetViewOnBottom.
+	Layer Layer
+
+	// ZIndex is the stacking order of the view inside its layer.
+	ZIndex int
+
+	// If Visible is false, the view is not drawn and does not receive
+	// keyboard or mouse events, but it keeps its content and state. Views
+	// are visible by default.
+	Visible bool
+
+	// If Focusable is true, the view is part of the focus ring used by
+	// FocusNext and FocusPrev. Views are focusable by default.
+	Focusable bool
+
+	// TabIndex is the position of the view in the focus ring. Views with
+	// the same TabIndex are ordered by creation.
+	TabIndex int
+
+	// OnFocus and OnBlur, if not nil, are called when the view gains or
+	// loses the focus.
+	OnFocus, OnBlur func(g *Gui, v *View) error
+
+	// OnMouse, if not nil, is called for every mouse event received by the
+	// view, before the mouse bindings set with SetMouseBinding.
+	OnMouse func(g *Gui, v *View, ev *MouseEvent) error
+
+	// OnResize, if not nil, is called before drawing the view when its size
+	// has changed since the last time it was drawn.
+	OnResize func(g *Gui, v *View, width, height int) error
+
+	// OnChange, if not nil, is called before drawing the view when its
+	// content has been modified, either by writing to it or by editing.
+	OnChange func(g *Gui, v *View) error
+
+	// Group allows to handle a set of views together, for instance a dialog
+	// and its child views. See SetGroupOnTop, MoveGroup, SetGroupVisible
+	// and DeleteGroup. Views with an empty Group do not belong to any group.
+	Group string
+
 	// If Wrap is true, the content that is written to this View is
 	// automatically wrapped when it is longer than its width. If true the
 	// view's x-origin will be ignored.
@@ -95,13 +164,39 @@ type View struct {
 	Autoscroll bool
 
 	// If Frame is true, Title allows to configure a title for the view.
+	// Titles that do not fit are truncated with an ellipsis.
 	Title string
 
+	// TitleAlign allows to align the title on the top edge of the frame.
+	TitleAlign Alignment
+
+	// TitleSegments allows to draw additional text, with its own alignment
+	// and colors, on the top edge of the frame. For instance, a status on
+	// the right.
+	TitleSegments []TitleSegment
+
+	// If Frame is true, Footer allows to configure a text drawn on the
+	// bottom edge of the frame. It uses the title colors.
+	Footer string
+
+	// FooterAlign allows to align the footer on the bottom edge of the
+	// frame.
+	FooterAlign Alignment
+
+	// FooterSegments allows to draw additional text on the bottom edge of
+	// the frame, like TitleSegments.
+	FooterSegments []TitleSegment
+
 	// If Mask is true, the View will display the mask instead of the real
 	// content
 	Mask rune
 }
 
+// Padding represents the space left on each side of a rectangle.
+type Padding struct {
+	Top, Right, Bottom, Left int
+}
+
 type viewLine struct {
 	linesX, linesY int // coordinates relative to v.lines
 	line           []cell
@@ -216,22 +311,47 @@ func (line lineType) wordWrap(width, indentFirst, indentSubsequent int) []lineTy
 // newView returns a new View object.
 func newView(name string, x0, y0, x1, y1 int, mode OutputMode) *View {
 	v := &View{
-		name:    name,
-		x0:      x0,
-		y0:      y0,
-		x1:      x1,
-		y1:      y1,
-		Frame:   true,
-		Editor:  DefaultEditor,
-		tainted: true,
-		ei:      newEscapeInterpreter(mode),
+		name:      name,
+		x0:        x0,
+		y0:        y0,
+		x1:        x1,
+		y1:        y1,
+		Frame:     true,
+		Visible:   true,
+		Focusable: true,
+		Editor:    DefaultEditor,
+		tainted:   true,
+		ei:        newEscapeInterpreter(mode),
 	}
 	return v
 }
 
-// Size returns the number of visible columns and rows in the View.
+// Size returns the number of visible columns and rows in the View. They are
+// 0 if the padding or the scrollbars take all the room.
 func (v *View) Size() (x, y int) {
-	return v.x1 - v.x0 - 1, v.y1 - v.y0 - 1
+	x = v.x1 - v.x0 - 1 - v.Padding.Left - v.Padding.Right
+	y = v.y1 - v.y0 - 1 - v.Padding.Top - v.Padding.Bottom
+	if v.scrollbarInside() {
+		if v.VScrollbar {
+			x--
+		}
+		if v.HScrollbar && !v.Wrap {
+			y--
+		}
+	}
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	return x, y
+}
+
+// origin returns the absolute position of the top-left corner of the
+// view's content.
+func (v *View) origin() (x, y int) {
+	return v.x0 + 1 + v.Padding.Left, v.y0 + 1 + v.Padding.Top
 }
 
 // Name returns the name of the view.
@@ -272,7 +392,8 @@ func (v *View) setRune(x, y int, ch rune, fgColor, bgColor Attribute) error {
 		bgColor = v.SelBgColor
 	}
 
-	termbox.SetCell(v.x0+x+1, v.y0+y+1, ch,
+	x0, y0 := v.origin()
+	termbox.SetCell(x0+x, y0+y, ch,
 		termbox.Attribute(fgColor), termbox.Attribute(bgColor))
 
 	return nil
@@ -320,6 +441,7 @@ func (v *View) Origin() (x, y int) {
 // be called to clear the view's buffer.
 func (v *View) Write(p []byte) (n int, err error) {
 	v.tainted = true
+	v.changed = true
 
 	for _, ch := range bytes.Runes(p) {
 		switch ch {
@@ -406,45 +528,16 @@ func (v *View) Rewind() {
 // draw re-draws the view's contents.
 func (v *View) draw() error {
 	maxX, maxY := v.Size()
+	if maxX <= 0 || maxY <= 0 {
+		// there is no room for the content
+		return nil
+	}
 
 	if v.Wrap {
-		if maxX == 0 {
-			return errors.New("X size of the view cannot be 0")
-		}
 		v.ox = 0
 	}
 	if v.tainted {
-		wrapInitialRE = regexp.MustCompile(fmt.Sprintf(chunkPattern, maxX-v.IndentFirst))
-		wrapSubsequentRE = regexp.MustCompile(fmt.Sprintf(chunkPattern, maxX-v.IndentSubsequent))
-		v.viewLines = nil
-		for i, cells := range v.lines {
-			line := lineType(cells)
-			if v.Wrap {
-				if (v.WordWrap && len(line) == 0) || (!v.WordWrap && len(line) < maxX) {
-					vline := viewLine{linesX: 0, linesY: i, line: line}
-					v.viewLines = append(v.viewLines, vline)
-					continue
-				} else {
-					if v.WordWrap {
-						pos := 0
-						for _, wl := range line.wordWrap(maxX, v.IndentFirst, v.IndentSubsequent) {
-							vline := viewLine{linesX: pos, linesY: i, line: wl}
-							pos += len(wl)
-							v.viewLines = append(v.viewLines, vline)
-						}
-					} else {
-						for n, wl := range line.wrap(maxX) {
-							vline := viewLine{linesX: maxX * n, linesY: i, line: wl}
-							v.viewLines = append(v.viewLines, vline)
-						}
-					}
-				}
-			} else {
-				vline := viewLine{linesX: 0, linesY: i, line: line}
-				v.viewLines = append(v.viewLines, vline)
-			}
-		}
-		v.tainted = false
+		v.updateViewLines(maxX)
 	}
 
 	if v.Autoscroll && len(v.viewLines) > maxY {
@@ -486,6 +579,42 @@ func (v *View) draw() error {
 	return nil
 }
 
+// updateViewLines rebuilds the internal representation of the view's
+// buffer, wrapping the lines to the given width if needed.
+func (v *View) updateViewLines(maxX int) {
+	wrapInitialRE = regexp.MustCompile(fmt.Sprintf(chunkPattern, maxX-v.IndentFirst))
+	wrapSubsequentRE = regexp.MustCompile(fmt.Sprintf(chunkPattern, maxX-v.IndentSubsequent))
+	v.viewLines = nil
+	for i, cells := range v.lines {
+		line := lineType(cells)
+		if v.Wrap {
+			if (v.WordWrap && len(line) == 0) || (!v.WordWrap && len(line) < maxX) {
+				vline := viewLine{linesX: 0, linesY: i, line: line}
+				v.viewLines = append(v.viewLines, vline)
+				continue
+			} else {
+				if v.WordWrap {
+					pos := 0
+					for _, wl := range line.wordWrap(maxX, v.IndentFirst, v.IndentSubsequent) {
+						vline := viewLine{linesX: pos, linesY: i, line: wl}
+						pos += len(wl)
+						v.viewLines = append(v.viewLines, vline)
+					}
+				} else {
+					for n, wl := range line.wrap(maxX) {
+						vline := viewLine{linesX: maxX * n, linesY: i, line: wl}
+						v.viewLines = append(v.viewLines, vline)
+					}
+				}
+			}
+		} else {
+			vline := viewLine{linesX: 0, linesY: i, line: line}
+			v.viewLines = append(v.viewLines, vline)
+		}
+	}
+	v.tainted = false
+}
+
 // realPosition returns the position in the internal buffer corresponding to the
 // point (x, y) of the view.
 func (v *View) realPosition(vx, vy int) (x, y int, err error) {
@@ -516,6 +645,7 @@ func (v *View) realPosition(vx, vy int) (x, y int, err error) {
 // Clear empties the view's internal buffer.
 func (v *View) Clear() {
 	v.tainted = true
+	v.changed = true
 
 	v.lines = nil
 	v.viewLines = nil
@@ -523,12 +653,11 @@ func (v *View) Clear() {
 	v.clearRunes()
 }
 
-// clearRunes erases all the cells in the view.
+// clearRunes erases all the cells in the view, including its padding.
 func (v *View) clearRunes() {
-	maxX, maxY := v.Size()
-	for x := 0; x < maxX; x++ {
-		for y := 0; y < maxY; y++ {
-			termbox.SetCell(v.x0+x+1, v.y0+y+1, ' ',
+	for x := v.x0 + 1; x < v.x1; x++ {
+		for y := v.y0 + 1; y < v.y1; y++ {
+			termbox.SetCell(x, y, ' ',
 				termbox.Attribute(v.FgColor), termbox.Attribute(v.BgColor))
 		}
 	}
@@ -0,0 +1,41 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestViewSizePadding(t *testing.T) {
+	tests := []struct {
+		padding      Padding
+		wrap         bool
+		vScrollbar   bool
+		wantX, wantY int
+	}{
+		{Padding{}, false, false, 3, 3},
+		{Padding{Left: 1, Right: 1}, false, false, 1, 3},
+		{Padding{Left: 2, Right: 2}, false, false, 0, 3},
+		{Padding{Left: 2, Right: 2}, true, false, 0, 3},
+		{Padding{Top: 3, Bottom: 3}, true, false, 3, 0},
+		{Padding{Top: 2, Right: 3, Bottom: 2, Left: 3}, true, true, 0, 0},
+	}
+	for _, tt := range tests {
+		v := newView("a", 0, 0, 4, 4, OutputNormal)
+		v.Padding = tt.padding
+		v.Wrap = tt.wrap
+		v.VScrollbar = tt.vScrollbar
+		fmt.Fprintln(v, "some text that is longer than the view")
+
+		x, y := v.Size()
+		if x != tt.wantX || y != tt.wantY {
+			t.Errorf("padding %+v: got size %dx%d, want %dx%d", tt.padding, x, y, tt.wantX, tt.wantY)
+		}
+		if err := v.draw(); err != nil {
+			t.Errorf("padding %+v: draw: %v", tt.padding, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2014 The gotui Authors. All rights reserved.
+// Use of this source code is governed by an MIT license.
+// The license can be found in the LICENSE file.
+
+package gotui
+
+import "github.com/mattn/go-runewidth"
+
+// A Widget is a Manager that draws a reusable component, like a List, in a
+// rectangle of the screen. Its position can be changed between layouts, so
+// widgets can be placed by other managers, like the nodes of the layout
+// package, or by other widgets.
+type Widget interface {
+	Manager
+
+	// Name returns the name of the main view of the widget.
+	Name() string
+
+	// SetPosition sets the rectangle of the widget, with its top-left
+	// corner at (x0, y0) and the bottom-right one at (x1, y1), using the
+	// same coordinates as SetView.
+	SetPosition(x0, y0, x1, y1 int)
+}
+
+// widget holds the name and the position of a widget.
+type widget struct {
+	name           string
+	x0, y0, x1, y1 int
+}
+
+// Name returns the name of the main view of the widget.
+func (w *widget) Name() string {
+	return w.name
+}
+
+// SetPosition sets the rectangle of the widget.
+func (w *widget) SetPosition(x0, y0, x1, y1 int) {
+	w.x0, w.y0, w.x1, w.y1 = x0, y0, x1, y1
+}
+
+// runeWidth returns the number of cells taken by the rune on the terminal,
+// following the same rules as termbox.
+func runeWidth(r rune) int {
+	w := runewidth.RuneWidth(r)
+	if w == 0 || w == 2 && runewidth.IsAmbiguousWidth(r) {
+		return 1
+	}
+	return w
+}
+
+// TextWidth returns the number of cells taken by the text on the terminal,
+// following the same rules as termbox. Wide runes, like CJK ideographs,
+// take two cells.
+func TextWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// ellipsis returns the text used to mark truncated text.
+func (g *Gui) ellipsis() string {
+	if g.ASCII {
+		return "..."
+	}
+	return "…"
+}
+
+// setLines replaces the content of the view with the given lines. The view
+// is only marked as changed if its content is different, so its OnChange
+// handler is not called when a widget draws the same content again.
+func setLines(v *View, lines [][]cell) {
+	v.tainted = true
+	if sameLines(lines, v.lines) {
+		return
+	}
+	v.lines = lines
+	v.changed = true
+}
+
+// writeText replaces the content of the view with the given text, which can
+// contain escape sequences. Like setLines, the view is only marked as
+// changed if its content is different.
+func writeText(v *View, text string) {
+	lines, changed := v.lines, v.changed
+	v.Clear()
+	v.Write([]byte(text))
+	if sameLines(lines, v.lines) {
+		v.changed = changed
+	}
+}
+
+// sameLines returns if both contents have the same cells.
+func sameLines(a, b [][]cell) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for y := range a {
+		if len(a[y]) != len(b[y]) {
+			return false
+		}
+		for x := range a[y] {
+			if a[y][x] != b[y][x] {
+				return false
+			}
+		}
+	}
+	return true
+}